@@ -0,0 +1,236 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Clock is an ISO-8601 time of day, such as 09:41:34.5, optionally with a zone, such as
+// 09:41Z or 09:41+01:00. It is suitable for values such as opening hours and alarm times,
+// which recur every day.
+//
+// The zero value is midnight, without a zone.
+type Clock struct {
+	ns  time.Duration  // since midnight, in the range [0, 24h)
+	loc *time.Location // nil if there is no zone
+}
+
+// dayLength is the length of a day on a clock.
+const dayLength = 24 * time.Hour
+
+var _ json.Unmarshaler = &Clock{}
+
+// NewClock returns the Clock for the given time of day, without a zone. The values
+// may be outside their usual ranges and wrap around midnight, so 25:00 is 01:00.
+func NewClock(hour, min, sec, nsec int) Clock {
+	d := time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(nsec)
+	return Clock{}.Add(d)
+}
+
+// ClockOf returns the time of day of t. Its zone is the zone in effect at t, as a fixed
+// offset, because a named location could have a different offset on other days.
+func ClockOf(t time.Time) Clock {
+	hh, mm, ss := t.Clock()
+	c := NewClock(hh, mm, ss, t.Nanosecond())
+	c.loc = time.UTC
+	if name, offset := t.Zone(); t.Location() != time.UTC {
+		c.loc = time.FixedZone(name, offset)
+	}
+	return c
+}
+
+// ParseClock parses an ISO-8601 time of day, which can have an optional leading T and an
+// optional zone designator. The extended format (e.g. T09:41:34.5+01:00) and the basic
+// format (e.g. 094134.5+0100) are both accepted, as are reduced precision (e.g. 09:41 or
+// 0941Z) and a decimal fraction on the lowest-order component (e.g. 09:41.5).
+func ParseClock(inp []byte) (Clock, error) {
+	return defaultParser().ParseClock(inp)
+}
+
+// ParseClockString parses an ISO-8601 time of day string; see ParseClock.
+func ParseClockString(inp string) (Clock, error) {
+	return ParseClock([]byte(inp))
+}
+
+// ParseClock is like the ParseClock function but uses the parser's maximum decimal places,
+// leniency and white space setting. The profile and forms do not apply.
+func (p Parser) ParseClock(inp []byte) (Clock, error) {
+	b := p.trim(inp)
+	c, err := p.parseClock(b)
+	if err != nil {
+		return Clock{}, withValue(err, b, inp)
+	}
+	return c, nil
+}
+
+// parseClock parses the time of day, once any white space has been trimmed.
+func (p Parser) parseClock(inp []byte) (Clock, error) {
+	f := p.newFields()
+	f.M, f.d = 1, 1
+
+	i := 0
+	if len(inp) > 0 && inp[0] == 'T' {
+		i++
+	}
+
+	// a time on its own is in basic format if its leading digits are hhmm or hhmmss
+	f.basic = scanDigits(inp, i)-i > 2
+
+	j, err := f.parseTime(inp, i)
+	if err != nil {
+		return Clock{}, err
+	}
+	if j == i {
+		if j < len(inp) {
+			return Clock{}, &SyntaxError{Value: string(inp), Element: "time", Rune: rune(inp[j])}
+		}
+		return Clock{}, &SyntaxError{Value: string(inp), Element: "time"}
+	}
+
+	if j < len(inp) {
+		if err = f.parseZone(inp, j); err != nil {
+			return Clock{}, err
+		}
+	}
+
+	if err = f.validate(inp); err != nil {
+		return Clock{}, err
+	}
+
+	c := NewClock(f.h, f.m, f.s, f.fraction)
+	c.loc = f.loc
+	return c, nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Hour returns the hour, in the range [0, 23].
+func (c Clock) Hour() int {
+	return int(c.ns / time.Hour)
+}
+
+// Minute returns the minute within the hour, in the range [0, 59].
+func (c Clock) Minute() int {
+	return int(c.ns % time.Hour / time.Minute)
+}
+
+// Second returns the second within the minute, in the range [0, 59].
+func (c Clock) Second() int {
+	return int(c.ns % time.Minute / time.Second)
+}
+
+// Nanosecond returns the nanosecond within the second, in the range [0, 999999999].
+func (c Clock) Nanosecond() int {
+	return int(c.ns % time.Second)
+}
+
+// SinceMidnight returns the time elapsed since midnight, ignoring any daylight saving
+// transitions, in the range [0, 24h).
+func (c Clock) SinceMidnight() time.Duration {
+	return c.ns
+}
+
+// Location returns the zone of the clock, or nil if it has none.
+func (c Clock) Location() *time.Location {
+	return c.loc
+}
+
+// Add returns the clock d later, wrapping around midnight; d may be negative.
+// The zone is unchanged.
+func (c Clock) Add(d time.Duration) Clock {
+	ns := (c.ns + d%dayLength) % dayLength
+	if ns < 0 {
+		ns += dayLength
+	}
+	return Clock{ns: ns, loc: c.loc}
+}
+
+// Before reports whether c is earlier in the day than u. Zones are ignored.
+func (c Clock) Before(u Clock) bool {
+	return c.ns < u.ns
+}
+
+// After reports whether c is later in the day than u. Zones are ignored.
+func (c Clock) After(u Clock) bool {
+	return c.ns > u.ns
+}
+
+// Compare compares c with u, ignoring zones. If c is earlier in the day than u, it
+// returns -1; if c is later, it returns +1; if they're the same, it returns 0.
+func (c Clock) Compare(u Clock) int {
+	switch {
+	case c.ns < u.ns:
+		return -1
+	case c.ns > u.ns:
+		return +1
+	}
+	return 0
+}
+
+// OnDate returns the Time at which the clock reads c on the date d, in the zone of the clock.
+// If the clock has no zone, UTC is used, as for Parse.
+func (c Clock) OnDate(d LocalDate) Time {
+	loc := c.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, dd := d.Date()
+	return Date(y, m, dd, c.Hour(), c.Minute(), c.Second(), c.Nanosecond(), loc)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the clock in ISO-8601 extended format, e.g. 09:41:34.5 or 09:41:34+01:00.
+func (c Clock) String() string {
+	return string(c.appendText(make([]byte, 0, 24), RFC3339Nano))
+}
+
+// appendText renders the clock using the time part of a date-time layout, omitting the zone
+// if the clock has none.
+func (c Clock) appendText(b []byte, layout string) []byte {
+	layout = layout[strings.IndexByte(layout, 'T')+1:]
+	loc := c.loc
+	if loc == nil {
+		layout = localLayout(layout)
+		loc = time.UTC
+	}
+	t := time.Date(2000, 1, 1, c.Hour(), c.Minute(), c.Second(), c.Nanosecond(), loc)
+	return t.AppendFormat(b, layout)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The clock is formatted in ISO-8601 extended format, with sub-second precision
+// controlled by MarshalTextFormat.
+func (c Clock) MarshalText() ([]byte, error) {
+	return c.appendText(make([]byte, 0, 24), MarshalTextFormat), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The clock is a quoted string in ISO-8601 extended format, with sub-second precision
+// controlled by MarshalTextFormat.
+func (c Clock) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 26)
+	b = append(b, '"')
+	b = c.appendText(b, MarshalTextFormat)
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The clock is expected to be in ISO-8601 format; see ParseClock.
+func (c *Clock) UnmarshalText(data []byte) (err error) {
+	*c, err = ParseClock(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a clock.
+func (c *Clock) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*c, err = ParseClock(b)
+	return err
+}
@@ -0,0 +1,133 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseClock_ok(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+
+	cases := []struct {
+		input    string
+		expected Clock
+		str      string
+	}{
+		{input: "T09:41", expected: NewClock(9, 41, 0, 0), str: "09:41:00"},
+		{input: "09:41:34.5", expected: NewClock(9, 41, 34, 500_000_000), str: "09:41:34.5"},
+		{input: "09:41:34,5", expected: NewClock(9, 41, 34, 500_000_000), str: "09:41:34.5"},
+		{input: "09", expected: NewClock(9, 0, 0, 0), str: "09:00:00"},
+		{input: "09.5", expected: NewClock(9, 30, 0, 0), str: "09:30:00"},
+		{input: "0941Z", expected: Clock{ns: 9*time.Hour + 41*time.Minute, loc: time.UTC}, str: "09:41:00Z"},
+		{input: "T094134+0100", expected: Clock{ns: 9*time.Hour + 41*time.Minute + 34*time.Second, loc: plus1}, str: "09:41:34+01:00"},
+		{input: "T09:41:34+01:00", expected: Clock{ns: 9*time.Hour + 41*time.Minute + 34*time.Second, loc: plus1}, str: "09:41:34+01:00"},
+		{input: "23:59:59.999999999", expected: NewClock(23, 59, 59, 999_999_999), str: "23:59:59.999999999"},
+		{input: "00:00", expected: Clock{}, str: "00:00:00"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			clock, err := ParseClockString(c.input)
+			expect.Error(err).ToBeNil(t)
+			expect.Number(clock.SinceMidnight()).ToBe(t, c.expected.SinceMidnight())
+			expect.String(clock.String()).ToBe(t, c.str)
+			if c.expected.Location() == nil {
+				expect.Any(clock.Location()).ToBeNil(t)
+			} else {
+				expect.Any(clock.OnDate(NewLocalDate(2017, 4, 24))).ToBe(t, c.expected.OnDate(NewLocalDate(2017, 4, 24)))
+			}
+		})
+	}
+}
+
+func TestParseClock_error(t *testing.T) {
+	cases := map[string]string{
+		"":                `Cannot parse "": invalid time`,
+		"T":               `Cannot parse "T": invalid time`,
+		"Z":               `Cannot parse "Z": invalid time at 'Z'`,
+		"2017-04-24":      `invalid zone`,
+		"25:00":           `hour`,
+		"09:60":           `minute`,
+		"0941:34":         "Unexpected character `:`",
+		"09:41X":          "Unexpected character `X`",
+		"09:41+01:00:00X": `zone`,
+	}
+
+	for inp, msg := range cases {
+		t.Run(inp, func(t *testing.T) {
+			_, err := ParseClockString(inp)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestClock_arithmetic(t *testing.T) {
+	c := NewClock(22, 30, 0, 0)
+
+	expect.Number(c.Hour()).ToBe(t, 22)
+	expect.Number(c.Minute()).ToBe(t, 30)
+	expect.Number(c.Second()).ToBe(t, 0)
+	expect.Number(c.Nanosecond()).ToBe(t, 0)
+
+	expect.Any(c.Add(time.Hour)).ToBe(t, NewClock(23, 30, 0, 0))
+	expect.Any(c.Add(2*time.Hour)).ToBe(t, NewClock(0, 30, 0, 0))
+	expect.Any(c.Add(-23*time.Hour)).ToBe(t, NewClock(23, 30, 0, 0))
+	expect.Any(c.Add(72*time.Hour)).ToBe(t, c)
+	expect.Any(NewClock(25, 0, 0, 0)).ToBe(t, NewClock(1, 0, 0, 0))
+	expect.Any(NewClock(0, -1, 0, 0)).ToBe(t, NewClock(23, 59, 0, 0))
+
+	expect.Bool(c.Before(NewClock(23, 0, 0, 0))).ToBeTrue(t)
+	expect.Bool(c.After(NewClock(23, 0, 0, 0))).ToBeFalse(t)
+	expect.Number(c.Compare(NewClock(1, 0, 0, 0))).ToBe(t, 1)
+	expect.Number(c.Compare(c)).ToBe(t, 0)
+}
+
+func TestClock_OnDate(t *testing.T) {
+	d := NewLocalDate(2017, 4, 24)
+	expect.Any(NewClock(9, 41, 0, 0).OnDate(d)).ToBe(t, Date(2017, 4, 24, 9, 41, 0, 0, time.UTC))
+
+	c, err := ParseClockString("09:41+01:00")
+	expect.Error(err).ToBeNil(t)
+	expect.Any(c.OnDate(d)).ToBe(t, Date(2017, 4, 24, 8, 41, 0, 0, time.UTC))
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	expect.Error(err).ToBeNil(t)
+	summer := ClockOf(time.Date(2017, 7, 1, 9, 41, 0, 0, berlin))
+	expect.String(summer.String()).ToBe(t, "09:41:00+02:00")
+	expect.String(ClockOf(time.Date(2017, 7, 1, 9, 41, 0, 0, time.UTC)).String()).ToBe(t, "09:41:00Z")
+}
+
+func TestClock_Marshaling(t *testing.T) {
+	c := NewClock(9, 41, 34, 502_000_000)
+
+	t.Run("text", func(t *testing.T) {
+		b, err := c.MarshalText()
+		expect.String(b, err).ToEqual(t, "09:41:34.502")
+
+		var c2 Clock
+		err = c2.UnmarshalText(b)
+		expect.Any(c2, err).ToBe(t, c)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(c)
+		expect.String(b, err).ToEqual(t, `"09:41:34.502"`)
+
+		var c2 Clock
+		err = json.Unmarshal(b, &c2)
+		expect.Any(c2, err).ToBe(t, c)
+
+		expect.Any(c2.UnmarshalJSON([]byte(`0941`))).ToBe(t, ErrNotString)
+		expect.Error(c2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+
+	t.Run("format", func(t *testing.T) {
+		defer func() { MarshalTextFormat = RFC3339Nano }()
+		MarshalTextFormat = ISO8601MilliComma
+		b, err := Clock{ns: c.ns, loc: time.UTC}.MarshalText()
+		expect.String(b, err).ToEqual(t, "09:41:34,502Z")
+	})
+}
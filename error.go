@@ -19,10 +19,16 @@ var (
 	ErrNotString = errors.New("iso8601: Invalid json type (expected string)")
 
 	// ErrPrecision indicates that there was too much precision (characters) given to parse
-	// for the fraction of a second of the input time.
-	ErrPrecision = errors.New("iso8601: Too many characters in fraction of second precision")
+	// for the decimal fraction of the input time; at most nine decimal places are allowed.
+	ErrPrecision = errors.New("iso8601: Too many characters in decimal fraction precision")
 )
 
+// errYearRange reports that the year y cannot be marshaled by method, because RFC 3339 requires
+// four-digit years. Only a Formatter renders such years, as expanded years.
+func errYearRange(method string, y int) error {
+	return fmt.Errorf("%s: year %d is outside the range 0-9999; use a Formatter to render it as an expanded year", method, y)
+}
+
 func newUnexpectedCharacterError(c rune) error {
 	return &UnexpectedCharacterError{Character: c}
 }
@@ -36,17 +42,24 @@ func (e *UnexpectedCharacterError) Error() string {
 	return fmt.Sprintf("iso8601: Unexpected character `%c`", e.Character)
 }
 
+// SyntaxError indicates that part of the input is malformed. Element names the part
+// that could not be parsed and Reason, if not blank, explains what was wrong with it.
 type SyntaxError struct {
 	Value   string
 	Element string
 	Rune    rune
+	Reason  string
 }
 
 func (e *SyntaxError) Error() string {
-	if e.Rune == 0 {
-		return fmt.Sprintf("iso8601: Cannot parse %q: invalid %s", e.Value, e.Element)
+	msg := fmt.Sprintf("iso8601: Cannot parse %q: invalid %s", e.Value, e.Element)
+	if e.Rune != 0 {
+		msg += fmt.Sprintf(" at '%c'", e.Rune)
+	}
+	if e.Reason != "" {
+		msg += "; " + e.Reason
 	}
-	return fmt.Sprintf("iso8601: Cannot parse %q: invalid %s at '%c'", e.Value, e.Element, e.Rune)
+	return msg
 }
 
 type RangeError struct {
@@ -0,0 +1,101 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TimeSecond, TimeMilli, TimeMicro and TimeNano are Times that are always marshaled in RFC 3339
+// format with a fixed number of decimal places: none, three, six or nine respectively, e.g.
+// 2017-04-24T09:41:34.502Z for TimeMilli. Unlike Time, they do not depend on MarshalTextFormat,
+// nor on any other package-level setting, so they allow different fields (e.g. for different
+// consumers) to be marshaled with different precisions, concurrently:
+//
+//	type Record struct {
+//		Created  iso8601.TimeMilli `json:"created"`  // for Salesforce
+//		Modified iso8601.TimeNano  `json:"modified"`
+//	}
+//
+// The time is truncated, not rounded, to the precision. Years outside the range 0-9999 are an
+// error, as required by RFC 3339. They are unmarshaled in the same way as Time.
+type (
+	TimeSecond struct{ Time }
+	TimeMilli  struct{ Time }
+	TimeMicro  struct{ Time }
+	TimeNano   struct{ Time }
+)
+
+// These layouts have a fixed number of decimal places, unlike RFC3339Milli etc.
+const (
+	rfc3339FixedMilli = "2006-01-02T15:04:05.000Z07:00"
+	rfc3339FixedMicro = "2006-01-02T15:04:05.000000Z07:00"
+	rfc3339FixedNano  = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+var (
+	_ json.Unmarshaler = &TimeSecond{}
+	_ json.Unmarshaler = &TimeMilli{}
+	_ json.Unmarshaler = &TimeMicro{}
+	_ json.Unmarshaler = &TimeNano{}
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TimeSecond) MarshalText() ([]byte, error) {
+	return marshalFixed(t.Time.Time, RFC3339, false, "TimeSecond.MarshalText")
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeSecond) MarshalJSON() ([]byte, error) {
+	return marshalFixed(t.Time.Time, RFC3339, true, "TimeSecond.MarshalJSON")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TimeMilli) MarshalText() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedMilli, false, "TimeMilli.MarshalText")
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeMilli) MarshalJSON() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedMilli, true, "TimeMilli.MarshalJSON")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TimeMicro) MarshalText() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedMicro, false, "TimeMicro.MarshalText")
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeMicro) MarshalJSON() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedMicro, true, "TimeMicro.MarshalJSON")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TimeNano) MarshalText() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedNano, false, "TimeNano.MarshalText")
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeNano) MarshalJSON() ([]byte, error) {
+	return marshalFixed(t.Time.Time, rfc3339FixedNano, true, "TimeNano.MarshalJSON")
+}
+
+// marshalFixed renders t using the layout, quoted if required for JSON.
+func marshalFixed(t time.Time, layout string, quoted bool, method string) ([]byte, error) {
+	return appendFixed(make([]byte, 0, len(layout)+2), t, layout, quoted, method)
+}
+
+// appendFixed appends t rendered using the layout, quoted if required for JSON.
+func appendFixed(b []byte, t time.Time, layout string, quoted bool, method string) ([]byte, error) {
+	if y := t.Year(); y < 0 || y >= 10000 {
+		return nil, errYearRange(method, y)
+	}
+
+	if quoted {
+		b = append(b, '"')
+	}
+	b = t.AppendFormat(b, layout)
+	if quoted {
+		b = append(b, '"')
+	}
+	return b, nil
+}
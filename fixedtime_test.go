@@ -0,0 +1,69 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestFixedTime_Marshaling(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+	tm := Date(2017, 4, 24, 9, 41, 34, 502_123_456, plus1)
+
+	type record struct {
+		S TimeSecond `json:"s"`
+		L TimeMilli  `json:"l"`
+		U TimeMicro  `json:"u"`
+		N TimeNano   `json:"n"`
+	}
+
+	expected := `{"s":"2017-04-24T09:41:34+01:00",` +
+		`"l":"2017-04-24T09:41:34.502+01:00",` +
+		`"u":"2017-04-24T09:41:34.502123+01:00",` +
+		`"n":"2017-04-24T09:41:34.502123456+01:00"}`
+
+	t.Run("json", func(t *testing.T) {
+		defer func() { MarshalTextFormat = RFC3339Nano }()
+		MarshalTextFormat = ISO8601MilliComma // has no effect
+
+		b, err := json.Marshal(record{S: TimeSecond{tm}, L: TimeMilli{tm}, U: TimeMicro{tm}, N: TimeNano{tm}})
+		expect.String(b, err).ToEqual(t, expected)
+
+		var r record
+		err = json.Unmarshal([]byte(expected), &r)
+		expect.Error(err).ToBeNil(t)
+		expect.Any(r.S.Time).ToBe(t, tm.Truncate(time.Second))
+		expect.Any(r.L.Time).ToBe(t, tm.Truncate(time.Millisecond))
+		expect.Any(r.U.Time).ToBe(t, tm.Truncate(time.Microsecond))
+		expect.Any(r.N.Time).ToBe(t, tm)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		b, err := TimeMilli{tm}.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T09:41:34.502+01:00")
+
+		// trailing zeros are kept
+		b, err = TimeMicro{Date(2017, 4, 24, 9, 41, 34, 0, time.UTC)}.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T09:41:34.000000Z")
+
+		// the precision of the input is not kept
+		var s TimeSecond
+		err = s.UnmarshalText([]byte("2017-04"))
+		expect.Error(err).ToBeNil(t)
+		b, err = s.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-01T00:00:00Z")
+
+		var u TimeMilli
+		err = u.UnmarshalText([]byte("2017-04-24T09:41:34.502+01:00"))
+		expect.Any(u.Time, err).ToBe(t, tm.Truncate(time.Millisecond))
+	})
+
+	t.Run("year out of range", func(t *testing.T) {
+		_, err := TimeNano{Date(-44, 3, 15, 0, 0, 0, 0, time.UTC)}.MarshalJSON()
+		expect.Error(err).ToContain(t, "TimeNano.MarshalJSON: year -44 is outside the range 0-9999")
+		_, err = TimeSecond{Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)}.MarshalText()
+		expect.Error(err).ToContain(t, "TimeSecond.MarshalText: year 10000 is outside the range 0-9999")
+	})
+}
@@ -0,0 +1,372 @@
+package iso8601
+
+import "time"
+
+// ZoneStyle determines how a Formatter renders the zone designator.
+type ZoneStyle uint8
+
+const (
+	// ZoneAuto renders ±hh:mm in the extended notation and ±hhmm in the basic notation.
+	// It is the zero value.
+	ZoneAuto ZoneStyle = iota
+
+	// ZoneExtended renders ±hh:mm, in either notation.
+	ZoneExtended
+
+	// ZoneBasic renders ±hhmm, in either notation.
+	ZoneBasic
+
+	// ZoneHours renders ±hh, unless the offset is not a whole number of hours, in which case
+	// it is rendered as for ZoneAuto.
+	ZoneHours
+
+	// ZoneOmitted renders no zone designator, so the time is rendered as the local time in
+	// its location.
+	ZoneOmitted
+)
+
+// Formatter renders Times in any of the ISO-8601 representations that Parse accepts.
+// The zero value renders the extended notation with a calendar date and a time to the second,
+// e.g. 2017-04-24T09:41:34+01:00 or 2017-04-24T08:41:34Z, as RFC 3339 requires. Each setting
+// is altered by a With method, which returns a modified copy, e.g.
+//
+//	f := iso8601.Formatter{}.WithForm(iso8601.FormBasic | iso8601.FormWeek).WithDecimals(3)
+//	s := f.Format(t) // e.g. 2017W171T094134.502+0100
+//
+// Lower-order components are truncated, not rounded. A zone offset of zero is rendered as Z,
+// unless WithNumericUTC is used. Years outside the range 0000-9999 are rendered as expanded
+// years; see WithExpandedYear.
+//
+// A Formatter is immutable and is safe for concurrent use.
+type Formatter struct {
+	form       Form
+	precision  Precision // zero means PrecisionSecond
+	decimals   int
+	zone       ZoneStyle
+	yearDigits int // the extra digits in an expanded year; zero means 2
+	comma      bool
+	numericUTC bool
+	expanded   bool
+	endOfDay   bool
+	suffix     bool
+}
+
+// WithForm returns a copy of f that renders the notation and kind of date in form. If form
+// includes FormBasic, the basic notation is used, otherwise the extended notation. If form
+// includes FormWeek, a week date is rendered; otherwise, if it includes FormOrdinal, an ordinal
+// date is rendered; otherwise a calendar date is rendered.
+func (f Formatter) WithForm(form Form) Formatter {
+	f.form = form
+	return f
+}
+
+// WithPrecision returns a copy of f that renders components down to p, e.g. PrecisionMinute
+// renders 2017-04-24T09:41+01:00. A precision that does not apply to the kind of date is
+// replaced by the next coarser one that does, e.g. PrecisionMonth renders only the year of
+// a week date. PrecisionFraction is the same as PrecisionSecond; see WithDecimals.
+// A year and month are always rendered in the extended notation, e.g. 2017-04, because
+// ISO-8601 does not allow the basic notation for them.
+func (f Formatter) WithPrecision(p Precision) Formatter {
+	f.precision = p
+	return f
+}
+
+// WithDecimals returns a copy of f that renders a decimal fraction of the lowest-order time
+// component with exactly n decimal places, which must be between 0 (the default) and 9. For
+// example, with PrecisionMinute, WithDecimals(2) renders 09:41.56.
+func (f Formatter) WithDecimals(n int) Formatter {
+	f.decimals = min(max(n, 0), maxDigits)
+	return f
+}
+
+// WithComma returns a copy of f that uses a comma as the decimal sign, as ISO-8601 prefers,
+// instead of a full stop.
+func (f Formatter) WithComma() Formatter {
+	f.comma = true
+	return f
+}
+
+// WithZone returns a copy of f that renders the zone designator in the given style.
+func (f Formatter) WithZone(style ZoneStyle) Formatter {
+	f.zone = style
+	return f
+}
+
+// WithNumericUTC returns a copy of f that renders a zone offset of zero as a number, e.g.
+// +00:00, instead of Z.
+func (f Formatter) WithNumericUTC() Formatter {
+	f.numericUTC = true
+	return f
+}
+
+// WithExpandedYear returns a copy of f that renders every year as an expanded year, with a
+// sign and n extra digits, e.g. +002017 when n is 2. Without this, only years outside the
+// range 0000-9999 are expanded, with two extra digits.
+func (f Formatter) WithExpandedYear(n int) Formatter {
+	f.expanded = true
+	f.yearDigits = min(max(n, 1), 5)
+	return f
+}
+
+// WithEndOfDay returns a copy of f that renders midnight at the start of a day as 24:00 at
+// the end of the previous day, e.g. 2017-04-24T24:00:00Z rather than 2017-04-25T00:00:00Z,
+// which is the same instant. It has no effect unless the time of day is rendered.
+func (f Formatter) WithEndOfDay() Formatter {
+	f.endOfDay = true
+	return f
+}
+
+// WithTimeZoneSuffix returns a copy of f that appends an RFC 9557 time zone suffix if the
+// Time has a named location, e.g. 2017-04-24T09:41:34+01:00[Europe/London]; see
+// Time.FormatRFC9557.
+func (f Formatter) WithTimeZoneSuffix() Formatter {
+	f.suffix = true
+	return f
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Format renders t as a string.
+func (f Formatter) Format(t Time) string {
+	return string(f.AppendTo(make([]byte, 0, 48), t))
+}
+
+// AppendTo appends the textual representation of t to dst and returns the extended buffer.
+// It does not allocate unless dst is too small.
+func (f Formatter) AppendTo(dst []byte, t Time) []byte {
+	basic := f.form&FormBasic != 0
+	form, p := f.dateForm()
+
+	tm := t.Time
+	endOfDay := f.endOfDay && p >= PrecisionHour && isMidnight(tm)
+	if endOfDay {
+		tm = tm.AddDate(0, 0, -1)
+	}
+
+	b := dst
+	y := tm.Year()
+	wy, w := tm.ISOWeek()
+	if form == weekDate {
+		// a week date belongs to the ISO week-numbering year, which can differ near the new year
+		y = wy
+	}
+
+	switch p {
+	case PrecisionCentury:
+		return f.appendYear(b, floorDiv(y, 100), 2)
+	case PrecisionYear:
+		return f.appendYear(b, y, 4)
+	}
+
+	switch form {
+	case weekDate:
+		b = f.appendYear(b, y, 4)
+		b = appendSeparator(b, '-', basic)
+		b = append(b, 'W')
+		b = appendInt(b, w, 2)
+		if p == PrecisionWeek {
+			return b
+		}
+		b = appendSeparator(b, '-', basic)
+		b = appendInt(b, isoWeekday(tm.Weekday()), 1)
+
+	case ordinalDate:
+		b = f.appendYear(b, y, 4)
+		b = appendSeparator(b, '-', basic)
+		b = appendInt(b, tm.YearDay(), 3)
+
+	default:
+		// ISO-8601 does not allow a year and month in basic notation, which looks like YYMMDD
+		b = f.appendYear(b, y, 4)
+		b = appendSeparator(b, '-', basic && p > PrecisionMonth)
+		b = appendInt(b, int(tm.Month()), 2)
+		if p == PrecisionMonth {
+			return b
+		}
+		b = appendSeparator(b, '-', basic)
+		b = appendInt(b, tm.Day(), 2)
+	}
+
+	if p == PrecisionDay {
+		return b
+	}
+
+	b = append(b, 'T')
+	hh, mm, ss := tm.Clock()
+	if endOfDay {
+		hh = 24
+	}
+
+	// the remainder is carried into the fraction of the lowest-order component
+	remainder := time.Duration(tm.Nanosecond())
+	unit := time.Second
+
+	b = appendInt(b, hh, 2)
+	switch p {
+	case PrecisionHour:
+		remainder += time.Duration(mm)*time.Minute + time.Duration(ss)*time.Second
+		unit = time.Hour
+
+	case PrecisionMinute:
+		b = appendSeparator(b, ':', basic)
+		b = appendInt(b, mm, 2)
+		remainder += time.Duration(ss) * time.Second
+		unit = time.Minute
+
+	default:
+		b = appendSeparator(b, ':', basic)
+		b = appendInt(b, mm, 2)
+		b = appendSeparator(b, ':', basic)
+		b = appendInt(b, ss, 2)
+	}
+
+	if f.decimals > 0 {
+		if f.comma {
+			b = append(b, ',')
+		} else {
+			b = append(b, '.')
+		}
+		for i := 0; i < f.decimals; i++ {
+			remainder *= 10
+			b = append(b, byte(remainder/unit)+'0')
+			remainder %= unit
+		}
+	}
+
+	b = f.appendZone(b, tm, basic)
+
+	if f.suffix {
+		b = appendZoneSuffix(b, t.Location())
+	}
+
+	return b
+}
+
+// dateForm returns the kind of date to be rendered, and the precision adjusted to suit it.
+func (f Formatter) dateForm() (dateForm, Precision) {
+	p := f.precision
+	switch p {
+	case PrecisionUnspecified, PrecisionFraction:
+		p = PrecisionSecond
+	}
+
+	switch {
+	case f.form&FormWeek != 0:
+		if p == PrecisionMonth {
+			p = PrecisionYear
+		}
+		return weekDate, p
+
+	case f.form&FormOrdinal != 0:
+		if p == PrecisionMonth || p == PrecisionWeek {
+			p = PrecisionYear
+		}
+		return ordinalDate, p
+	}
+
+	if p == PrecisionWeek {
+		p = PrecisionMonth
+	}
+	return calendarDate, p
+}
+
+// appendYear appends the year (or century) y, zero-padded to width digits, or as an
+// expanded year if required.
+func (f Formatter) appendYear(b []byte, y, width int) []byte {
+	if !f.expanded && y >= 0 && y < pow10(width) {
+		return appendInt(b, y, width)
+	}
+
+	if y >= 0 {
+		b = append(b, '+')
+	}
+	if f.yearDigits == 0 {
+		return appendInt(b, y, width+2)
+	}
+	return appendInt(b, y, width+f.yearDigits)
+}
+
+// appendZone appends the zone designator of t.
+func (f Formatter) appendZone(b []byte, t time.Time, basic bool) []byte {
+	if f.zone == ZoneOmitted {
+		return b
+	}
+
+	_, offset := t.Zone()
+	if offset == 0 && !f.numericUTC {
+		return append(b, 'Z')
+	}
+
+	if offset < 0 {
+		b = append(b, '-')
+		offset = -offset
+	} else {
+		b = append(b, '+')
+	}
+
+	hh, mm, ss := offset/3600, offset/60%60, offset%60
+	b = appendInt(b, hh, 2)
+
+	style := f.zone
+	if style == ZoneHours && mm == 0 && ss == 0 {
+		return b
+	}
+
+	colon := style == ZoneExtended || (style != ZoneBasic && !basic)
+	b = appendSeparator(b, ':', !colon)
+	b = appendInt(b, mm, 2)
+	if ss != 0 {
+		b = appendSeparator(b, ':', !colon)
+		b = appendInt(b, ss, 2)
+	}
+	return b
+}
+
+// appendSeparator appends the separator c, unless the notation is basic.
+func appendSeparator(b []byte, c byte, basic bool) []byte {
+	if basic {
+		return b
+	}
+	return append(b, c)
+}
+
+// style returns a Formatter that renders times in the style in which the fields f were written.
+func (f *fields) style() Formatter {
+	s := Formatter{precision: f.p, comma: f.decimal == ',', endOfDay: f.isEndOfDay()}
+
+	if f.basic {
+		s.form |= FormBasic
+	}
+	switch f.form {
+	case weekDate:
+		s.form |= FormWeek
+	case ordinalDate:
+		s.form |= FormOrdinal
+	}
+
+	if f.p == PrecisionFraction {
+		s.precision, s.decimals = f.fractionOf, f.fractionDigits
+	}
+
+	if f.expanded {
+		s.expanded, s.yearDigits = true, f.yearDigits
+	}
+
+	switch {
+	case f.zone == nil:
+		s.zone = ZoneOmitted
+	case f.zone[0] == 'Z':
+	default:
+		s.numericUTC = true
+		switch zoneFormOf(f.zone) {
+		case zoneHours:
+			s.zone = ZoneHours
+		case zoneBasic:
+			s.zone = ZoneBasic
+		case zoneExtended:
+			s.zone = ZoneExtended
+		}
+	}
+
+	return s
+}
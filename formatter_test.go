@@ -0,0 +1,145 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestFormatter_AppendTo(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+	tm := Date(2017, 4, 24, 9, 41, 34, 502_123_456, plus1)
+	utc := tm.UTC()
+
+	cases := []struct {
+		f        Formatter
+		t        Time
+		expected string
+	}{
+		{f: Formatter{}, t: tm, expected: "2017-04-24T09:41:34+01:00"},
+		{f: Formatter{}, t: utc, expected: "2017-04-24T08:41:34Z"},
+		{f: Formatter{}.WithForm(FormBasic), t: tm, expected: "20170424T094134+0100"},
+		{f: Formatter{}.WithForm(FormWeek), t: tm, expected: "2017-W17-1T09:41:34+01:00"},
+		{f: Formatter{}.WithForm(FormBasic | FormWeek), t: tm, expected: "2017W171T094134+0100"},
+		{f: Formatter{}.WithForm(FormOrdinal), t: tm, expected: "2017-114T09:41:34+01:00"},
+		{f: Formatter{}.WithForm(FormBasic | FormOrdinal), t: tm, expected: "2017114T094134+0100"},
+
+		{f: Formatter{}.WithPrecision(PrecisionCentury), t: tm, expected: "20"},
+		{f: Formatter{}.WithPrecision(PrecisionYear), t: tm, expected: "2017"},
+		{f: Formatter{}.WithPrecision(PrecisionMonth), t: tm, expected: "2017-04"},
+		{f: Formatter{}.WithPrecision(PrecisionMonth).WithForm(FormBasic), t: tm, expected: "2017-04"},
+		{f: Formatter{}.WithPrecision(PrecisionWeek), t: tm, expected: "2017-04"},
+		{f: Formatter{}.WithPrecision(PrecisionWeek).WithForm(FormWeek), t: tm, expected: "2017-W17"},
+		{f: Formatter{}.WithPrecision(PrecisionMonth).WithForm(FormWeek), t: tm, expected: "2017"},
+		{f: Formatter{}.WithPrecision(PrecisionDay), t: tm, expected: "2017-04-24"},
+		{f: Formatter{}.WithPrecision(PrecisionHour), t: tm, expected: "2017-04-24T09+01:00"},
+		{f: Formatter{}.WithPrecision(PrecisionMinute), t: tm, expected: "2017-04-24T09:41+01:00"},
+		{f: Formatter{}.WithPrecision(PrecisionFraction), t: tm, expected: "2017-04-24T09:41:34+01:00"},
+
+		{f: Formatter{}.WithDecimals(3), t: tm, expected: "2017-04-24T09:41:34.502+01:00"},
+		{f: Formatter{}.WithDecimals(9), t: tm, expected: "2017-04-24T09:41:34.502123456+01:00"},
+		{f: Formatter{}.WithDecimals(6).WithComma(), t: utc, expected: "2017-04-24T08:41:34,502123Z"},
+		{f: Formatter{}.WithDecimals(3).WithForm(FormBasic), t: tm, expected: "20170424T094134.502+0100"},
+		{f: Formatter{}.WithDecimals(2).WithPrecision(PrecisionMinute), t: tm, expected: "2017-04-24T09:41.57+01:00"},
+		{f: Formatter{}.WithDecimals(4).WithPrecision(PrecisionHour), t: tm, expected: "2017-04-24T09.6929+01:00"},
+		{f: Formatter{}.WithDecimals(3), t: Date(2017, 4, 24, 9, 41, 34, 0, time.UTC), expected: "2017-04-24T09:41:34.000Z"},
+
+		{f: Formatter{}.WithZone(ZoneBasic), t: tm, expected: "2017-04-24T09:41:34+0100"},
+		{f: Formatter{}.WithZone(ZoneExtended).WithForm(FormBasic), t: tm, expected: "20170424T094134+01:00"},
+		{f: Formatter{}.WithZone(ZoneHours), t: tm, expected: "2017-04-24T09:41:34+01"},
+		{f: Formatter{}.WithZone(ZoneHours), t: tm.In(time.FixedZone("", -(5*3600 + 30*60))), expected: "2017-04-24T03:11:34-05:30"},
+		{f: Formatter{}.WithZone(ZoneOmitted), t: tm, expected: "2017-04-24T09:41:34"},
+		{f: Formatter{}.WithNumericUTC(), t: utc, expected: "2017-04-24T08:41:34+00:00"},
+		{f: Formatter{}.WithNumericUTC().WithForm(FormBasic), t: utc, expected: "20170424T084134+0000"},
+		{f: Formatter{}, t: tm.In(time.FixedZone("", 3600+45*60+30)), expected: "2017-04-24T10:27:04+01:45:30"},
+
+		{f: Formatter{}.WithExpandedYear(2), t: tm, expected: "+002017-04-24T09:41:34+01:00"},
+		{f: Formatter{}.WithExpandedYear(2).WithPrecision(PrecisionCentury), t: tm, expected: "+0020"},
+		{f: Formatter{}.WithPrecision(PrecisionDay), t: Date(-44, 3, 15, 0, 0, 0, 0, time.UTC), expected: "-000044-03-15"},
+		{f: Formatter{}.WithPrecision(PrecisionDay), t: Date(12017, 4, 24, 0, 0, 0, 0, time.UTC), expected: "+012017-04-24"},
+		{f: Formatter{}.WithPrecision(PrecisionCentury), t: Date(-150, 6, 1, 0, 0, 0, 0, time.UTC), expected: "-0002"},
+		{f: Formatter{}.WithPrecision(PrecisionCentury), t: Date(-200, 6, 1, 0, 0, 0, 0, time.UTC), expected: "-0002"},
+		{f: Formatter{}.WithPrecision(PrecisionCentury), t: Date(-99, 6, 1, 0, 0, 0, 0, time.UTC), expected: "-0001"},
+
+		// week dates belong to the ISO week-numbering year
+		{f: Formatter{}.WithPrecision(PrecisionYear).WithForm(FormWeek), t: Date(2019, 12, 30, 0, 0, 0, 0, time.UTC), expected: "2020"},
+		{f: Formatter{}.WithPrecision(PrecisionMonth).WithForm(FormWeek), t: Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), expected: "2020"},
+		{f: Formatter{}.WithPrecision(PrecisionCentury).WithForm(FormWeek), t: Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), expected: "19"},
+		{f: Formatter{}.WithPrecision(PrecisionDay).WithForm(FormWeek), t: Date(2019, 12, 30, 0, 0, 0, 0, time.UTC), expected: "2020-W01-1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			s := c.f.Format(c.t)
+			expect.String(s).ToBe(t, c.expected)
+			expect.String(string(c.f.AppendTo([]byte("x"), c.t))).ToBe(t, "x"+c.expected)
+
+			// whatever is rendered can be parsed
+			_, err := ParseString(s)
+			expect.Error(err).ToBeNil(t)
+		})
+	}
+}
+
+func TestFormatter_roundTrip(t *testing.T) {
+	inputs := []string{
+		"2017-04-24T09:41:34.502+01:00",
+		"20170424T094134,502+0100",
+		"2017-W17-1T09:41:34+01",
+		"2017114T0941Z",
+		"2017-04-24T09.5Z",
+	}
+
+	formatters := map[string]Formatter{
+		"2017-04-24T09:41:34.502+01:00": Formatter{}.WithDecimals(3),
+		"20170424T094134,502+0100":      Formatter{}.WithForm(FormBasic).WithDecimals(3).WithComma(),
+		"2017-W17-1T09:41:34+01":        Formatter{}.WithForm(FormWeek).WithZone(ZoneHours),
+		"2017114T0941Z":                 Formatter{}.WithForm(FormBasic | FormOrdinal).WithPrecision(PrecisionMinute),
+		"2017-04-24T09.5Z":              Formatter{}.WithPrecision(PrecisionHour).WithDecimals(1),
+	}
+
+	for _, inp := range inputs {
+		tm, err := ParseString(inp)
+		expect.Error(err).ToBeNil(t)
+		expect.String(formatters[inp].Format(tm)).ToBe(t, inp)
+	}
+}
+
+func TestFormatter_endOfDay(t *testing.T) {
+	tm, err := ParseString("2017-04-24T24:00:00Z")
+	expect.Error(err).ToBeNil(t)
+
+	expect.String(Formatter{}.Format(tm)).ToBe(t, "2017-04-25T00:00:00Z")
+	expect.String(Formatter{}.WithEndOfDay().Format(tm)).ToBe(t, "2017-04-24T24:00:00Z")
+	expect.String(Formatter{}.WithEndOfDay().WithForm(FormOrdinal).WithPrecision(PrecisionMinute).Format(tm)).ToBe(t, "2017-114T24:00Z")
+	expect.String(Formatter{}.WithEndOfDay().WithPrecision(PrecisionDay).Format(tm)).ToBe(t, "2017-04-25")
+
+	// any midnight is rendered as 24:00, however it was parsed
+	expect.String(Formatter{}.WithEndOfDay().Format(Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))).ToBe(t, "2016-12-31T24:00:00Z")
+	expect.String(Formatter{}.WithEndOfDay().Format(Date(2017, 1, 1, 0, 0, 0, 1, time.UTC))).ToBe(t, "2017-01-01T00:00:00Z")
+}
+
+func TestFormatter_WithTimeZoneSuffix(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+
+	f := Formatter{}.WithTimeZoneSuffix()
+	expect.String(f.Format(Date(2022, 7, 8, 0, 14, 7, 0, london))).ToBe(t, "2022-07-08T00:14:07+01:00[Europe/London]")
+	expect.String(f.Format(Date(2022, 7, 8, 0, 14, 7, 0, time.UTC))).ToBe(t, "2022-07-08T00:14:07Z")
+	expect.String(f.Format(Date(2022, 7, 8, 0, 14, 7, 0, time.FixedZone("Europe/Nowhere", 3600)))).ToBe(t, "2022-07-08T00:14:07+01:00")
+}
+
+func TestFormatter_allocations(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+	tm := Date(2017, 4, 24, 9, 41, 34, 502_123_456, london)
+
+	f := Formatter{}.WithForm(FormWeek).WithDecimals(9).WithComma().WithTimeZoneSuffix()
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendTo(buf[:0], tm)
+	})
+	expect.Number(allocs).ToBe(t, 0)
+}
@@ -0,0 +1,444 @@
+package iso8601
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// intervalForm distinguishes the ways in which an interval can be written.
+type intervalForm uint8
+
+const (
+	startEnd    intervalForm = iota // start/end
+	startPeriod                     // start/period
+	periodEnd                       // period/end
+)
+
+// Interval is an ISO-8601 time interval. It is expressed as a start and an end
+// (e.g. 2007-03-01T13:00:00Z/2008-05-11T15:30:00Z), as a start and a period
+// (e.g. 2007-03-01T13:00:00Z/P1Y2M10DT2H30M) or as a period and an end
+// (e.g. P1Y2M10DT2H30M/2008-05-11T15:30:00Z). The interval remembers which
+// form it has, and is marshaled in the same form.
+//
+// Intervals are half-open: they include their start but not their end.
+type Interval struct {
+	start, end PreciseTime
+	period     Period
+	form       intervalForm
+}
+
+var _ json.Unmarshaler = &Interval{}
+
+// NewInterval returns the interval between start and end.
+func NewInterval(start, end Time) Interval {
+	return Interval{start: PreciseTime{Time: start}, end: PreciseTime{Time: end}, form: startEnd}
+}
+
+// IntervalFrom returns the interval that starts at start and lasts for the period p.
+func IntervalFrom(start Time, p Period) Interval {
+	return Interval{start: PreciseTime{Time: start}, period: p, form: startPeriod}
+}
+
+// IntervalTo returns the interval that lasts for the period p and ends at end.
+func IntervalTo(p Period, end Time) Interval {
+	return Interval{period: p, end: PreciseTime{Time: end}, form: periodEnd}
+}
+
+// ParseInterval parses an ISO-8601 time interval in one of the forms start/end, start/period
+// or period/end. A double hyphen can be used instead of the solidus, e.g. start--end.
+//
+// The start and end are parsed using ParseWithPrecision, so that they are marshaled with the
+// same precision, and the period using ParsePeriod.
+// In the start/end form, the end can be abbreviated by omitting its leading components when
+// they are the same as those of the start, e.g. 2007-12-14T13:30/15:30 ends at 2007-12-14T15:30
+// and so does 20071214T1330/1530. If such an end has no zone designator, it has the same zone
+// as the start. An end that has its own year is not abbreviated, e.g.
+// 2007-03-01T13:00:00Z/2008-05-11T15:30Z. The end must not be before the start.
+func ParseInterval(inp []byte) (Interval, error) {
+	sep := bytes.IndexByte(inp, '/')
+	next := sep + 1
+	if sep < 0 {
+		sep = bytes.Index(inp, []byte("--"))
+		next = sep + 2
+	}
+
+	if sep <= 0 || next == len(inp) {
+		return Interval{}, &SyntaxError{Value: string(inp), Element: "interval"}
+	}
+
+	first, second := inp[:sep], inp[next:]
+
+	switch {
+	case isPeriod(first) && isPeriod(second):
+		return Interval{}, &SyntaxError{Value: string(inp), Element: "interval", Reason: "at most one period is allowed"}
+
+	case isPeriod(first):
+		p, err := ParsePeriod(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		end, err := ParseWithPrecision(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return Interval{period: p, end: end, form: periodEnd}, nil
+
+	case isPeriod(second):
+		start, err := ParseWithPrecision(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		p, err := ParsePeriod(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return Interval{start: start, period: p, form: startPeriod}, nil
+	}
+
+	start, err := ParseWithPrecision(first)
+	if err != nil {
+		return Interval{}, err
+	}
+
+	end, err := ParseWithPrecision(completeEnd(first, second))
+	if err != nil {
+		return Interval{}, err
+	}
+	if end.Before(start.Time) {
+		return Interval{}, &SyntaxError{Value: string(inp), Element: "interval", Reason: "the end is before the start"}
+	}
+	return Interval{start: start, end: end, form: startEnd}, nil
+}
+
+// ParseIntervalString parses an ISO-8601 time interval string; see ParseInterval.
+func ParseIntervalString(inp string) (Interval, error) {
+	return ParseInterval([]byte(inp))
+}
+
+// isPeriod reports whether b looks like a period rather than a date-time.
+func isPeriod(b []byte) bool {
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		b = b[1:]
+	}
+	return len(b) > 0 && b[0] == 'P'
+}
+
+// completeEnd fills in the leading components of an abbreviated end from the start.
+// A date in the end replaces the same number of trailing date components of the start, e.g.
+// 2008-02-15/03-14 ends on 2008-03-14; in basic notation, it replaces the same number of
+// trailing digits, e.g. 20080215/0314. A time in the end replaces the whole time of the start,
+// e.g. 2007-12-14T13:30/15:30 and 20071214T1330/1530 both end at 15:30. An end without a 'T'
+// is a time if the start has a time and the end either contains ':' or is as short as the
+// start's time and has no hyphen; otherwise it is a date.
+// An end whose date has a sign, or is at least as long as the start's, is complete.
+func completeEnd(start, end []byte) []byte {
+	startDate, startTime, hasTime := bytes.Cut(start, []byte{'T'})
+	endDate, endTime, found := bytes.Cut(end, []byte{'T'})
+	if !found && hasTime && isTime(end, startTime) {
+		endDate, endTime, found = nil, end, true
+	}
+
+	prefix, ok := datePrefix(startDate, endDate)
+	if !ok {
+		return end
+	}
+
+	full := make([]byte, 0, len(start)+len(end))
+	full = append(full, prefix...)
+	full = append(full, endDate...)
+	if found {
+		full = append(full, 'T')
+		full = append(full, endTime...)
+		if hasTime && zoneIndex(endTime) == len(endTime) {
+			full = append(full, startTime[zoneIndex(startTime):]...)
+		}
+	}
+	return full
+}
+
+// isTime reports whether end, which has no 'T', is a time rather than a date.
+func isTime(end, startTime []byte) bool {
+	if bytes.IndexByte(end, ':') >= 0 {
+		return true
+	}
+	return bytes.IndexByte(end, '-') < 0 && countDigits(end) <= countDigits(startTime)
+}
+
+// datePrefix returns the leading components of the start date that are omitted from the end
+// date, or false if the end date is complete.
+func datePrefix(start, end []byte) ([]byte, bool) {
+	if len(end) == 0 {
+		return start, true
+	}
+	if end[0] == '+' || end[0] == '-' || len(start) < 2 {
+		return nil, false
+	}
+
+	if bytes.IndexByte(start[1:], '-') < 0 {
+		// basic notation
+		if len(end) >= len(start) || bytes.IndexByte(end, '-') >= 0 {
+			return nil, false
+		}
+		return start[:len(start)-len(end)], true
+	}
+
+	// extended notation: find the hyphen before the components that the end replaces
+	first, _, _ := bytes.Cut(end, []byte{'-'})
+	i := len(start)
+	for range bytes.Count(end, []byte{'-'}) + 1 {
+		i = bytes.LastIndexByte(start[:i], '-')
+		if i <= 0 {
+			return nil, false
+		}
+	}
+
+	// the first component of the end must be no longer than the one it replaces
+	replaced, _, _ := bytes.Cut(start[i+1:], []byte{'-'})
+	if len(first) > len(replaced) {
+		return nil, false
+	}
+	return start[:i+1], true
+}
+
+// countDigits returns the number of leading digits in b.
+func countDigits(b []byte) int {
+	for i, c := range b {
+		if !isDigit(c) {
+			return i
+		}
+	}
+	return len(b)
+}
+
+// zoneIndex returns the index of the zone designator in a time, or its length if it has none.
+func zoneIndex(tm []byte) int {
+	if z := bytes.IndexAny(tm, "Z+-"); z >= 0 {
+		return z
+	}
+	return len(tm)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Start returns the start of the interval.
+func (iv Interval) Start() Time {
+	if iv.form == periodEnd {
+		return iv.end.SubPeriod(iv.period)
+	}
+	return iv.start.Time
+}
+
+// End returns the end of the interval.
+func (iv Interval) End() Time {
+	if iv.form == startPeriod {
+		return iv.start.AddPeriod(iv.period)
+	}
+	return iv.end.Time
+}
+
+// Period returns the period of the interval. For an interval expressed as a start and
+// an end, this is computed using PeriodUntil.
+func (iv Interval) Period() Period {
+	if iv.form == startEnd {
+		return iv.start.PeriodUntil(iv.end.Time)
+	}
+	return iv.period
+}
+
+// Duration returns the exact duration of the interval, i.e. the time elapsed from its
+// start to its end.
+func (iv Interval) Duration() time.Duration {
+	return iv.End().Sub(iv.Start().Time)
+}
+
+// Contains reports whether t is within the interval, i.e. it is not before the start
+// and is before the end.
+func (iv Interval) Contains(t Time) bool {
+	return !t.Before(iv.Start()) && t.Before(iv.End())
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the interval in ISO-8601 format, in the same form in which it was expressed.
+func (iv Interval) String() string {
+	b, _ := iv.appendText(make([]byte, 0, 64))
+	return string(b)
+}
+
+// appendText renders the interval in the same form in which it was expressed, rendering
+// the start and end as MarshalText would. It returns false if a year is outside the
+// range 0-9999; even so, the text is complete.
+func (iv Interval) appendText(b []byte) ([]byte, bool) {
+	ok1, ok2 := true, true
+
+	switch iv.form {
+	case periodEnd:
+		b = iv.period.AppendFormat(b)
+	default:
+		b, ok1 = appendTimeText(b, iv.start)
+	}
+
+	b = append(b, '/')
+
+	switch iv.form {
+	case startPeriod:
+		b = iv.period.AppendFormat(b)
+	default:
+		b, ok2 = appendTimeText(b, iv.end)
+	}
+
+	return b, ok1 && ok2
+}
+
+// appendTimeText appends t as MarshalText would, falling back to its String form
+// if that fails.
+func appendTimeText(b []byte, t PreciseTime) ([]byte, bool) {
+	if b2, ok := t.appendText(b); ok {
+		return b2, true
+	}
+	return append(b, t.String()...), false
+}
+
+// outOfRangeYear returns the year of the start or end that appendText could not render.
+func (iv Interval) outOfRangeYear() int {
+	if y := iv.start.Year(); iv.form != periodEnd && (y < 0 || y >= 10000) {
+		return y
+	}
+	return iv.end.Year()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The interval is formatted in ISO-8601 format, in the same form in which it was expressed.
+func (iv Interval) MarshalText() ([]byte, error) {
+	b, ok := iv.appendText(make([]byte, 0, 64))
+	if !ok {
+		return nil, errYearRange("Interval.MarshalText", iv.outOfRangeYear())
+	}
+	return b, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The interval is a quoted string in ISO-8601 format, in the same form in which it was expressed.
+func (iv Interval) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 66)
+	b = append(b, '"')
+	b, ok := iv.appendText(b)
+	if !ok {
+		return nil, errYearRange("Interval.MarshalJSON", iv.outOfRangeYear())
+	}
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The interval is expected to be in ISO-8601 format; see ParseInterval.
+func (iv *Interval) UnmarshalText(data []byte) (err error) {
+	*iv, err = ParseInterval(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into an interval.
+func (iv *Interval) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*iv, err = ParseInterval(b)
+	return err
+}
+
+//-------------------------------------------------------------------------------------------------
+// Allen's interval relations. Each compares the instants at the ends of the intervals, so
+// intervals in different zones can be compared.
+
+// Before reports whether iv ends before o starts, with a gap between them.
+func (iv Interval) Before(o Interval) bool {
+	return iv.End().Before(o.Start())
+}
+
+// Meets reports whether iv ends at the instant that o starts.
+func (iv Interval) Meets(o Interval) bool {
+	return iv.End().Equal(o.Start())
+}
+
+// Overlaps reports whether iv starts before o and ends during o.
+func (iv Interval) Overlaps(o Interval) bool {
+	s1, e1, s2, e2 := iv.Start(), iv.End(), o.Start(), o.End()
+	return s1.Before(s2) && s2.Before(e1) && e1.Before(e2)
+}
+
+// During reports whether iv starts after o starts and ends before o ends.
+func (iv Interval) During(o Interval) bool {
+	return iv.Start().After(o.Start()) && iv.End().Before(o.End())
+}
+
+// Starts reports whether iv starts at the same instant as o and ends before o ends.
+func (iv Interval) Starts(o Interval) bool {
+	return iv.Start().Equal(o.Start()) && iv.End().Before(o.End())
+}
+
+// Finishes reports whether iv ends at the same instant as o and starts after o starts.
+func (iv Interval) Finishes(o Interval) bool {
+	return iv.End().Equal(o.End()) && iv.Start().After(o.Start())
+}
+
+// Equal reports whether iv and o start at the same instant and end at the same instant.
+// They may be expressed differently, e.g. one with a period and the other with an end.
+func (iv Interval) Equal(o Interval) bool {
+	return iv.Start().Equal(o.Start()) && iv.End().Equal(o.End())
+}
+
+// IsEmpty reports whether the interval contains no instants, i.e. its end is not after its start.
+func (iv Interval) IsEmpty() bool {
+	return !iv.End().After(iv.Start())
+}
+
+// Intersect returns the interval in which iv and o overlap. It returns false if they do not
+// overlap. Each end of the result is the same Time as the corresponding end of iv or o,
+// including its zone; when both ends are the same instant, the one from iv is used.
+func (iv Interval) Intersect(o Interval) (Interval, bool) {
+	start, end := iv.Start(), iv.End()
+	if s2 := o.Start(); s2.After(start) {
+		start = s2
+	}
+	if e2 := o.End(); e2.Before(end) {
+		end = e2
+	}
+
+	if !end.After(start) {
+		return Interval{}, false
+	}
+	return NewInterval(start, end), true
+}
+
+// Union returns the interval that covers both iv and o. It returns false if there is a gap
+// between them, in which case the union is not an interval. Each end of the result is the
+// same Time as the corresponding end of iv or o, including its zone; when both ends are the
+// same instant, the one from iv is used.
+func (iv Interval) Union(o Interval) (Interval, bool) {
+	if iv.Before(o) || o.Before(iv) {
+		return Interval{}, false
+	}
+
+	start, end := iv.Start(), iv.End()
+	if s2 := o.Start(); s2.Before(start) {
+		start = s2
+	}
+	if e2 := o.End(); e2.After(end) {
+		end = e2
+	}
+	return NewInterval(start, end), true
+}
+
+// Gap returns the interval between iv and o, from the end of the earlier to the start of the
+// later. It returns false if they overlap or meet.
+func (iv Interval) Gap(o Interval) (Interval, bool) {
+	switch {
+	case iv.Before(o):
+		return NewInterval(iv.End(), o.Start()), true
+	case o.Before(iv):
+		return NewInterval(o.End(), iv.Start()), true
+	}
+	return Interval{}, false
+}
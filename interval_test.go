@@ -0,0 +1,310 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseInterval_ok(t *testing.T) {
+	cases := []struct {
+		input      string
+		start, end Time
+		str        string
+	}{
+		{
+			input: "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "P1Y2M10DT2H30M/2008-05-11T15:30:00Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00Z--2008-05-11T15:30:00Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+			str:   "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+		},
+		{
+			input: "2007-12-14T13:30Z/15:30",
+			start: Date(2007, 12, 14, 13, 30, 0, 0, time.UTC),
+			end:   Date(2007, 12, 14, 15, 30, 0, 0, time.UTC),
+			str:   "2007-12-14T13:30Z/2007-12-14T15:30Z",
+		},
+		{
+			input: "2007-12-14T13:30+01:00/15:30",
+			start: Date(2007, 12, 14, 13, 30, 0, 0, time.FixedZone("", 3600)),
+			end:   Date(2007, 12, 14, 15, 30, 0, 0, time.FixedZone("", 3600)),
+			str:   "2007-12-14T13:30+01:00/2007-12-14T15:30+01:00",
+		},
+		{
+			input: "2007-11-13T09:00Z/15T17:00",
+			start: Date(2007, 11, 13, 9, 0, 0, 0, time.UTC),
+			end:   Date(2007, 11, 15, 17, 0, 0, 0, time.UTC),
+			str:   "2007-11-13T09:00Z/2007-11-15T17:00Z",
+		},
+		{
+			input: "2008-02-15/03-14",
+			start: Date(2008, 2, 15, 0, 0, 0, 0, time.UTC),
+			end:   Date(2008, 3, 14, 0, 0, 0, 0, time.UTC),
+			str:   "2008-02-15/2008-03-14",
+		},
+		{
+			input: "2017-04-24T13:00Z/24:00",
+			start: Date(2017, 4, 24, 13, 0, 0, 0, time.UTC),
+			end:   Date(2017, 4, 25, 0, 0, 0, 0, time.UTC),
+			str:   "2017-04-24T13:00Z/2017-04-25T00:00Z",
+		},
+		{
+			// full ends written with less precision than the start are not abbreviated
+			input: "2007-03-01T13:00:00Z/2008-05-11T15:30Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00.5Z/2008-05-11T15:30:00Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 500_000_000, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00.5+01:00/2008-05-11T15:30Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 500_000_000, time.FixedZone("", 3600)),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00Z/2008-05-11",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00Z/+002008-05-11T15:30Z",
+			start: Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			end:   Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+			str:   "2007-03-01T13:00:00Z/2008-05-11T15:30Z",
+		},
+		{
+			// an abbreviated end replaces the components of the start from the hour down
+			input: "2007-12-14T13:30:00.5Z/15:30",
+			start: Date(2007, 12, 14, 13, 30, 0, 500_000_000, time.UTC),
+			end:   Date(2007, 12, 14, 15, 30, 0, 0, time.UTC),
+			str:   "2007-12-14T13:30:00.5Z/2007-12-14T15:30Z",
+		},
+		{
+			// basic notation
+			input: "20071214T1330/1530",
+			start: Date(2007, 12, 14, 13, 30, 0, 0, time.UTC),
+			end:   Date(2007, 12, 14, 15, 30, 0, 0, time.UTC),
+			str:   "2007-12-14T13:30Z/2007-12-14T15:30Z",
+		},
+		{
+			input: "20071113T0900+0100/15T1700",
+			start: Date(2007, 11, 13, 9, 0, 0, 0, time.FixedZone("", 3600)),
+			end:   Date(2007, 11, 15, 17, 0, 0, 0, time.FixedZone("", 3600)),
+			str:   "2007-11-13T09:00+01:00/2007-11-15T17:00+01:00",
+		},
+		{
+			input: "20080215/0314",
+			start: Date(2008, 2, 15, 0, 0, 0, 0, time.UTC),
+			end:   Date(2008, 3, 14, 0, 0, 0, 0, time.UTC),
+			str:   "2008-02-15/2008-03-14",
+		},
+		{
+			// an end of digits alone is an hour, not a year
+			input: "2007-12-14T13:30/15",
+			start: Date(2007, 12, 14, 13, 30, 0, 0, time.UTC),
+			end:   Date(2007, 12, 14, 15, 0, 0, 0, time.UTC),
+			str:   "2007-12-14T13:30Z/2007-12-14T15Z",
+		},
+		{
+			input: "2008-02-15/2009",
+			start: Date(2008, 2, 15, 0, 0, 0, 0, time.UTC),
+			end:   Date(2009, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "2017-04/P1M",
+			start: Date(2017, 4, 1, 0, 0, 0, 0, time.UTC),
+			end:   Date(2017, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			iv, err := ParseIntervalString(c.input)
+			expect.Error(err).ToBeNil(t)
+			expect.Any(iv.Start()).ToBe(t, c.start)
+			expect.Any(iv.End()).ToBe(t, c.end)
+			expect.Number(iv.Duration()).ToBe(t, c.end.Sub(c.start.Time))
+
+			str := c.str
+			if str == "" {
+				str = c.input
+			}
+			expect.String(iv.String()).ToBe(t, str)
+		})
+	}
+}
+
+func TestParseInterval_error(t *testing.T) {
+	cases := map[string]string{
+		"":                            `Cannot parse "": invalid interval`,
+		"2007-03-01T13:00:00Z":        `Cannot parse "2007-03-01T13:00:00Z": invalid interval`,
+		"/2008-05-11T15:30:00Z":       `Cannot parse "/2008-05-11T15:30:00Z": invalid interval`,
+		"2007-03-01T13:00:00Z/":       `Cannot parse "2007-03-01T13:00:00Z/": invalid interval`,
+		"P1D/P2D":                     `Cannot parse "P1D/P2D": invalid interval; at most one period is allowed`,
+		"P1X/2008-05-11T15:30:00Z":    `Cannot parse "P1X": invalid period at 'X'`,
+		"2007-03-01T13:00:00Z/P1X":    `Cannot parse "P1X": invalid period at 'X'`,
+		"2007-03-01T25:00:00Z/P1D":    `hour`,
+		"2007-12-14T13:30/25:30":      `hour`,
+		"2008-02-15/2008-02-14T25:00": `hour`,
+		"2007-114T09:00Z/15T17:00":    `Cannot parse "2007-15T17:00Z"`,
+		"2007-12-14T13:30/12:30":      `Cannot parse "2007-12-14T13:30/12:30": invalid interval; the end is before the start`,
+		"20071214T1330/1230":          `the end is before the start`,
+		"2008-02-15/2008-02-14":       `the end is before the start`,
+	}
+
+	for input, msg := range cases {
+		t.Run(input, func(t *testing.T) {
+			iv, err := ParseIntervalString(input)
+			expect.Error(err).ToContain(t, msg)
+			expect.Any(iv).ToBe(t, Interval{})
+		})
+	}
+}
+
+func TestInterval_accessors(t *testing.T) {
+	start := Date(2017, 1, 31, 10, 0, 0, 0, time.UTC)
+	end := Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	iv := NewInterval(start, end)
+	expect.Any(iv.Period()).ToBe(t, NewPeriod(0, 0, 0, 29, 2, 0, 0))
+	expect.Number(iv.Duration()).ToBe(t, 29*24*time.Hour+2*time.Hour)
+
+	expect.Bool(iv.Contains(start)).ToBeTrue(t)
+	expect.Bool(iv.Contains(start.Add(time.Hour))).ToBeTrue(t)
+	expect.Bool(iv.Contains(end.Add(-time.Nanosecond))).ToBeTrue(t)
+	expect.Bool(iv.Contains(end)).ToBeFalse(t)
+	expect.Bool(iv.Contains(start.Add(-time.Nanosecond))).ToBeFalse(t)
+
+	p := NewPeriod(0, 0, 0, 1, 0, 0, 0)
+	expect.Any(IntervalFrom(start, p).End()).ToBe(t, Date(2017, 2, 1, 10, 0, 0, 0, time.UTC))
+	expect.Any(IntervalFrom(start, p).Period()).ToBe(t, p)
+	expect.Any(IntervalTo(p, end).Start()).ToBe(t, Date(2017, 2, 28, 12, 0, 0, 0, time.UTC))
+	expect.Any(IntervalTo(p, end).Period()).ToBe(t, p)
+}
+
+func TestInterval_Marshaling(t *testing.T) {
+	iv, err := ParseIntervalString("2007-03-01T13:00:00Z/P1Y2M10DT2H30M")
+	expect.Error(err).ToBeNil(t)
+
+	t.Run("text", func(t *testing.T) {
+		b, err := iv.MarshalText()
+		expect.String(b, err).ToEqual(t, "2007-03-01T13:00:00Z/P1Y2M10DT2H30M")
+
+		var iv2 Interval
+		err = iv2.UnmarshalText(b)
+		expect.Any(iv2, err).ToBe(t, iv)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(iv)
+		expect.String(b, err).ToEqual(t, `"2007-03-01T13:00:00Z/P1Y2M10DT2H30M"`)
+
+		var iv2 Interval
+		err = json.Unmarshal(b, &iv2)
+		expect.Any(iv2, err).ToBe(t, iv)
+
+		expect.Any(iv2.UnmarshalJSON([]byte(`P1D`))).ToBe(t, ErrNotString)
+		expect.Error(iv2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+
+	t.Run("year out of range", func(t *testing.T) {
+		iv := NewInterval(Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+		_, err := iv.MarshalText()
+		expect.Error(err).ToContain(t, "Interval.MarshalText: year 10000 is outside the range 0-9999")
+		_, err = json.Marshal(iv)
+		expect.Error(err).ToContain(t, "Interval.MarshalJSON: year 10000 is outside the range 0-9999")
+	})
+}
+
+func TestInterval_relations(t *testing.T) {
+	at := func(h int) Time { return Date(2024, 1, 1, h, 0, 0, 0, time.UTC) }
+	iv := func(s, e int) Interval { return NewInterval(at(s), at(e)) }
+
+	a := iv(10, 12)
+	cases := []struct {
+		name                                                     string
+		o                                                        Interval
+		before, meets, overlaps, during, starts, finishes, equal bool
+	}{
+		{name: "before", o: iv(13, 14), before: true},
+		{name: "meets", o: iv(12, 14), meets: true},
+		{name: "overlaps", o: iv(11, 14), overlaps: true},
+		{name: "during", o: iv(9, 13), during: true},
+		{name: "starts", o: iv(10, 13), starts: true},
+		{name: "finishes", o: iv(9, 12), finishes: true},
+		{name: "equal", o: IntervalFrom(at(10), NewPeriod(0, 0, 0, 0, 2, 0, 0)), equal: true},
+		{name: "after", o: iv(7, 9)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expect.Bool(a.Before(c.o)).ToBe(t, c.before)
+			expect.Bool(a.Meets(c.o)).ToBe(t, c.meets)
+			expect.Bool(a.Overlaps(c.o)).ToBe(t, c.overlaps)
+			expect.Bool(a.During(c.o)).ToBe(t, c.during)
+			expect.Bool(a.Starts(c.o)).ToBe(t, c.starts)
+			expect.Bool(a.Finishes(c.o)).ToBe(t, c.finishes)
+			expect.Bool(a.Equal(c.o)).ToBe(t, c.equal)
+		})
+	}
+}
+
+func TestInterval_Intersect_Union_Gap(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+	a := NewInterval(Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	b := NewInterval(Date(2024, 1, 1, 12, 0, 0, 0, plus1), Date(2024, 1, 1, 15, 0, 0, 0, plus1))
+	c := NewInterval(Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), Date(2024, 1, 1, 14, 0, 0, 0, time.UTC))
+
+	// a and b overlap from 11:00 UTC to 12:00 UTC
+	x, ok := a.Intersect(b)
+	expect.Bool(ok).ToBeTrue(t)
+	expect.String(x.String()).ToBe(t, "2024-01-01T12:00:00+01:00/2024-01-01T12:00:00Z")
+
+	u, ok := a.Union(b)
+	expect.Bool(ok).ToBeTrue(t)
+	expect.String(u.String()).ToBe(t, "2024-01-01T10:00:00Z/2024-01-01T15:00:00+01:00")
+
+	_, ok = a.Gap(b)
+	expect.Bool(ok).ToBeFalse(t)
+
+	// a and c are separate
+	_, ok = a.Intersect(c)
+	expect.Bool(ok).ToBeFalse(t)
+
+	_, ok = a.Union(c)
+	expect.Bool(ok).ToBeFalse(t)
+
+	g, ok := c.Gap(a)
+	expect.Bool(ok).ToBeTrue(t)
+	expect.String(g.String()).ToBe(t, "2024-01-01T12:00:00Z/2024-01-01T13:00:00Z")
+
+	// meeting intervals have a union but no intersection or gap
+	d := NewInterval(Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), Date(2024, 1, 1, 13, 0, 0, 0, time.UTC))
+	_, ok = a.Intersect(d)
+	expect.Bool(ok).ToBeFalse(t)
+	_, ok = a.Gap(d)
+	expect.Bool(ok).ToBeFalse(t)
+	u, ok = a.Union(d)
+	expect.Bool(ok).ToBeTrue(t)
+	expect.String(u.String()).ToBe(t, "2024-01-01T10:00:00Z/2024-01-01T13:00:00Z")
+}
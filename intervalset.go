@@ -0,0 +1,109 @@
+package iso8601
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// IntervalSet is a set of instants, held as a sorted list of non-overlapping intervals.
+// Intervals that overlap or meet are merged into a single span, and empty intervals are
+// discarded. Each span is expressed as a start and an end, which are the same Time values
+// as the ends of the intervals from which it was made, including their zones.
+//
+// The zero value is an empty set.
+type IntervalSet struct {
+	spans []Interval
+}
+
+// NewIntervalSet returns the set of instants covered by any of the given intervals.
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	return IntervalSet{}.Add(intervals...)
+}
+
+// Add returns the set of instants covered by s or by any of the given intervals.
+// The receiver is not altered.
+func (s IntervalSet) Add(intervals ...Interval) IntervalSet {
+	all := make([]Interval, 0, len(s.spans)+len(intervals))
+	all = append(all, s.spans...)
+	for _, iv := range intervals {
+		if !iv.IsEmpty() {
+			all = append(all, NewInterval(iv.Start(), iv.End()))
+		}
+	}
+
+	slices.SortStableFunc(all, func(a, b Interval) int {
+		return a.start.Compare(b.start.Time)
+	})
+
+	spans := make([]Interval, 0, len(all))
+	for _, iv := range all {
+		n := len(spans)
+		if n > 0 && !iv.start.After(spans[n-1].end.Time) {
+			if iv.end.After(spans[n-1].end.Time) {
+				spans[n-1].end = iv.end
+			}
+		} else {
+			spans = append(spans, iv)
+		}
+	}
+
+	return IntervalSet{spans: spans}
+}
+
+// Len returns the number of spans in the set.
+func (s IntervalSet) Len() int {
+	return len(s.spans)
+}
+
+// IsEmpty reports whether the set contains no instants.
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.spans) == 0
+}
+
+// Intervals returns the spans in the set, in chronological order.
+func (s IntervalSet) Intervals() []Interval {
+	return slices.Clone(s.spans)
+}
+
+// All returns an iterator over the spans in the set, in chronological order.
+func (s IntervalSet) All() iter.Seq[Interval] {
+	return slices.Values(s.spans)
+}
+
+// Contains reports whether t is within any of the spans in the set.
+func (s IntervalSet) Contains(t Time) bool {
+	// find the first span that ends after t
+	i, _ := slices.BinarySearchFunc(s.spans, t, func(iv Interval, t Time) int {
+		if iv.end.After(t) {
+			return 1
+		}
+		return -1
+	})
+	return i < len(s.spans) && s.spans[i].Contains(t)
+}
+
+// Gaps returns the intervals between the spans in the set, in chronological order.
+func (s IntervalSet) Gaps() []Interval {
+	if len(s.spans) < 2 {
+		return nil
+	}
+
+	gaps := make([]Interval, 0, len(s.spans)-1)
+	for i := 1; i < len(s.spans); i++ {
+		gaps = append(gaps, NewInterval(s.spans[i-1].end.Time, s.spans[i].start.Time))
+	}
+	return gaps
+}
+
+// String renders the spans in the set in ISO-8601 format, separated by commas.
+func (s IntervalSet) String() string {
+	var b strings.Builder
+	for i, iv := range s.spans {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(iv.String())
+	}
+	return b.String()
+}
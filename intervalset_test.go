@@ -0,0 +1,65 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestIntervalSet(t *testing.T) {
+	at := func(h int) Time { return Date(2024, 1, 1, h, 0, 0, 0, time.UTC) }
+	iv := func(s, e int) Interval { return NewInterval(at(s), at(e)) }
+
+	s := NewIntervalSet(
+		iv(14, 16),
+		iv(9, 10),
+		iv(10, 11),
+		IntervalFrom(at(15), NewPeriod(0, 0, 0, 0, 2, 0, 0)),
+		iv(20, 20),
+		iv(19, 18),
+		iv(12, 13),
+	)
+
+	expect.Number(s.Len()).ToBe(t, 3)
+	expect.String(s.String()).ToBe(t, "2024-01-01T09:00:00Z/2024-01-01T11:00:00Z,"+
+		"2024-01-01T12:00:00Z/2024-01-01T13:00:00Z,"+
+		"2024-01-01T14:00:00Z/2024-01-01T17:00:00Z")
+
+	expect.Bool(s.Contains(at(8))).ToBeFalse(t)
+	expect.Bool(s.Contains(at(9))).ToBeTrue(t)
+	expect.Bool(s.Contains(at(10))).ToBeTrue(t)
+	expect.Bool(s.Contains(at(11))).ToBeFalse(t)
+	expect.Bool(s.Contains(at(12).Add(30 * time.Minute))).ToBeTrue(t)
+	expect.Bool(s.Contains(at(16))).ToBeTrue(t)
+	expect.Bool(s.Contains(at(17))).ToBeFalse(t)
+
+	gaps := s.Gaps()
+	expect.Slice(gaps).ToHaveLength(t, 2)
+	expect.String(gaps[0].String()).ToBe(t, "2024-01-01T11:00:00Z/2024-01-01T12:00:00Z")
+	expect.String(gaps[1].String()).ToBe(t, "2024-01-01T13:00:00Z/2024-01-01T14:00:00Z")
+
+	s2 := s.Add(iv(11, 12))
+	expect.Number(s.Len()).ToBe(t, 3)
+	expect.Number(s2.Len()).ToBe(t, 2)
+	expect.String(s2.Intervals()[0].String()).ToBe(t, "2024-01-01T09:00:00Z/2024-01-01T13:00:00Z")
+
+	n := 0
+	for range s2.All() {
+		n++
+	}
+	expect.Number(n).ToBe(t, 2)
+
+	expect.Bool(IntervalSet{}.IsEmpty()).ToBeTrue(t)
+	expect.Bool(IntervalSet{}.Contains(at(9))).ToBeFalse(t)
+	expect.Slice(IntervalSet{}.Gaps()).ToBeEmpty(t)
+}
+
+func TestIntervalSet_keepsZones(t *testing.T) {
+	plus2 := time.FixedZone("", 7200)
+	s := NewIntervalSet(
+		NewInterval(Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+		NewInterval(Date(2024, 1, 1, 13, 0, 0, 0, plus2), Date(2024, 1, 1, 16, 0, 0, 0, plus2)),
+	)
+	expect.String(s.String()).ToBe(t, "2024-01-01T10:00:00Z/2024-01-01T16:00:00+02:00")
+}
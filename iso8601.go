@@ -6,23 +6,19 @@
 package iso8601
 
 import (
+	"bytes"
+	"strconv"
 	"time"
 	"unicode/utf8"
 )
 
-const (
-	year uint = iota
-	month
-	day
-	hour
-	minute
-	second
-	millisecond
-)
-
 const (
 	// charStart is the binary position of the character `0`
 	charStart int = '0'
+
+	// maxDigits is the longest run of digits that is converted to an int;
+	// this avoids overflow on 32-bit platforms.
+	maxDigits = 9
 )
 
 // ParseISOZone parses the zone information in an ISO8061 date string.
@@ -110,197 +106,628 @@ func ParseISOZone(inp []byte) (*time.Location, error) {
 	return time.FixedZone(string(inp), offset), nil
 }
 
+// dateForm distinguishes the ways in which a date can be written.
+type dateForm uint8
+
+const (
+	calendarDate dateForm = iota // YYYY-MM-DD
+	weekDate                     // YYYY-Www-D
+	ordinalDate                  // YYYY-DDD
+)
+
+// fields holds the components of a date-time as they are scanned from the input.
+// For week dates, Y is initially the ISO week-numbering year and is replaced by the
+// calendar year (along with M and d) during validation. For ordinal dates, d is initially
+// the day of the year.
+type fields struct {
+	Y, M, d  int
+	w, wd    int // ISO week and day of the week, for week dates
+	h, m, s  int
+	fraction int            // nanoseconds
+	loc      *time.Location // nil if there is no zone designator
+	p        Precision
+	form     dateForm
+	basic    bool // the date was written in basic format
+
+	// these record how the input was written, so that it can be checked against a Profile
+	// and its style can be retained
+	expanded       bool      // the year has a sign
+	fractionOf     Precision // the component that has the decimal fraction
+	fractionDigits int
+	widths         [numFields]uint8 // the digits in each field of an extended date or time, if present
+	decimal        byte             // the decimal sign, or 0 if there is no fraction
+	zone           []byte           // the zone designator, or nil if there is none
+
+	// these are settings, copied from a Parser; the zero values give the default behaviour
+	yearDigits int  // the extra digits in an expanded year; zero means 2
+	decimals   int  // the maximum number of decimal places; if zero, maxDigits
+	truncate   bool // excess decimal places are dropped instead of being rejected
+	mixed      bool // a basic date may be followed by an extended time, and vice versa
+	negZero    bool // the offset -00:00 is accepted as UTC
+}
+
 // Parse parses an ISO8601 compliant date-time byte slice into a time.Time object.
+//
+// Both the extended format (e.g. 2017-04-24T09:41:34.502+01:00) and the basic
+// format (e.g. 20170424T094134.502+0100) are supported. The two notations must
+// not be mixed within one representation, so a basic date followed by an
+// extended time (or vice versa) is rejected with an *iso8601.SyntaxError; a
+// Parser with WithLenient accepts it. The zone offset is exempt from this rule
+// because +hhmm and +hh:mm are both in widespread use with either notation.
+//
+// As well as calendar dates, the date can be a week date (e.g. 2017-W17-1 or
+// 2017W171) or an ordinal date (e.g. 2017-114 or 2017114). If the day of the
+// week is omitted (e.g. 2017-W17), Monday is assumed. Ordinal dates follow the
+// same notation rule as calendar dates, so 2017114T09:41Z is rejected because
+// its date is basic and its time extended; write 2017114T0941Z or
+// 2017-114T09:41Z instead, or use a lenient Parser.
+//
+// Years outside the range 0000 to 9999 can be written as expanded years, which have a leading
+// sign and two extra digits, e.g. +002017-04-24 or -000044-03-15. A Parser can expect a
+// different number of digits (see Parser.WithExpandedYearDigits).
+//
+// The lowest-order time component may have a decimal fraction, so 09:41:34.5, 09:41.5
+// and 09.5 are all accepted. The decimal sign can be a full stop or a comma (e.g. 09:41:34,5).
+// The fraction can have up to nine decimal places and is converted exactly to nanoseconds.
+//
+// The time 24:00 (or 24:00:00, etc.) is accepted as the end of the day, provided that every other
+// time component is zero. It is normalized to midnight at the start of the next day; see also
+// PreciseTime.IsEndOfDay.
+//
+// Second 60 is rejected; see Parser.WithLeapSeconds.
+//
+// An RFC 9557 suffix is accepted, e.g. 2022-07-08T00:14:07+01:00[Europe/London]; see ParseRFC9557.
+// Its elective tags are ignored.
+//
+// Reduced-precision dates are accepted: a year and month (e.g. 2017-04), a year (e.g. 2017)
+// or a century (e.g. 20, meaning 2000-2099). The omitted components take their lowest values.
+// Use ParseWithPrecision to obtain the precision, so that it can be marshaled in the same form.
+//
+// If the input has no zone designator, it is assumed to be UTC; see also ParseInLocation
+// and ParseLocalDateTime.
+//
 // If any component of an input date-time is not within the expected range then an *iso8601.RangeError is returned.
+//
+// Parse has the default settings; a Parser can be used instead to configure it.
 func Parse(inp []byte) (Time, error) {
-	var (
-		Y         int
-		M         int
-		d         int
-		h         int
-		m         int
-		s         int
-		fraction  int
-		nfraction = 1 // counts amount of precision for the second fraction
-	)
-
-	// Always assume UTC by default
-	var loc = time.UTC
-
-	var c int
-	var p = year
-
-	var i int
-
-parse:
-	for ; i < len(inp); i++ {
-		switch inp[i] {
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			c = c * 10
-			c += int(inp[i]) - charStart
+	if bytes.IndexByte(inp, '[') >= 0 {
+		return defaultParser().Parse(inp)
+	}
 
-			if p == millisecond {
-				nfraction++
-			}
-		case '-':
-			if p < hour {
-				switch p {
-				case year:
-					Y = c
-				case month:
-					M = c
-				default:
-					return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-				}
-				p++
-				c = 0
-				continue
-			}
-			fallthrough
-		case '+':
-			switch p {
-			case hour:
-				h = c
-			case minute:
-				m = c
-			case second:
-				s = c
-			case millisecond:
-				fraction = c
-			default:
-				return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-			}
-			c = 0
-			var err error
-			loc, err = ParseISOZone(inp[i:])
-			if err != nil {
-				return Time{}, err
-			}
-			break parse
-		case 'T':
-			if p != day {
-				return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-			}
-			d = c
-			c = 0
-			p++
-		case ':':
-			switch p {
-			case hour:
-				h = c
-			case minute:
-				m = c
-			case second:
-				m = c
-			default:
-				return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-			}
-			c = 0
-			p++
-		case '.':
-			if p != second {
-				return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-			}
-			s = c
-			c = 0
-			p++
-		case 'Z':
-			switch p {
-			case hour:
-				h = c
-			case minute:
-				m = c
-			case second:
-				s = c
-			case millisecond:
-				fraction = int(c)
-			default:
-				return Time{}, newUnexpectedCharacterError(rune(inp[i]))
-			}
-			c = 0
-			if len(inp) != i+1 {
-				return Time{}, ErrRemainingData
-			}
-		default:
-			return Time{}, newUnexpectedCharacterError(rune(inp[i]))
+	// without a suffix, the default Parser only needs to scan and validate the fields
+	var f fields
+	if err := f.parse(inp); err != nil {
+		return Time{}, err
+	}
+	if err := f.validate(inp); err != nil {
+		return Time{}, err
+	}
+	if f.loc == nil {
+		f.loc = time.UTC
+	}
+	return Date(f.Y, time.Month(f.M), f.d, f.h, f.m, f.s, f.fraction, f.loc), nil
+}
+
+// ParseInLocation is like Parse but differs in two important ways. First, in the absence of a zone
+// designator, Parse interprets a time as UTC; ParseInLocation interprets the time as in the given
+// location. Second, when given a zone offset, ParseInLocation still returns a Time with that fixed
+// offset, not one in the given location. This mirrors time.ParseInLocation.
+func ParseInLocation(inp []byte, loc *time.Location) (Time, error) {
+	return defaultParser().WithLocation(loc).Parse(inp)
+}
+
+// ParseWithPrecision is like Parse but returns a PreciseTime, which carries the precision of
+// the input, i.e. its lowest-order component. This allows (for example) "April 2017" to be
+// distinguished from "2017-04-01T00:00Z", and MarshalText and MarshalJSON render it with the
+// same granularity.
+func ParseWithPrecision(inp []byte) (PreciseTime, error) {
+	return defaultParser().ParseWithPrecision(inp)
+}
+
+// parseDateTime parses the input, without any suffix, which is in the location loc
+// if it has no zone designator.
+func (p Parser) parseDateTime(inp []byte, loc *time.Location) (PreciseTime, error) {
+	f := p.newFields()
+	if err := f.parse(inp); err != nil {
+		return PreciseTime{}, err
+	}
+
+	if err := p.check(inp, &f); err != nil {
+		return PreciseTime{}, err
+	}
+
+	// a leap second is range-checked as second 59, then checked against the leap second table
+	leap := p.leapSeconds && f.s == 60
+	if leap {
+		f.s = 59
+	}
+
+	if err := f.validate(inp); err != nil {
+		return PreciseTime{}, err
+	}
+	if f.loc == nil {
+		f.loc = loc
+	}
+
+	if leap {
+		f.s = 60
+	}
+
+	// 24:00 and leap seconds are normalized to the start of the next day or minute
+	t := Date(f.Y, time.Month(f.M), f.d, f.h, f.m, f.s, f.fraction, f.loc)
+
+	if leap && !isLeapSecond(t.Time.Add(-time.Duration(f.fraction)).UTC()) {
+		return PreciseTime{}, &RangeError{Value: string(inp), Element: "second", Given: 60, Min: 0, Max: 59}
+	}
+
+	pt := PreciseTime{Time: t, precision: f.p, endOfDay: f.isEndOfDay(), leapSecond: leap}
+	pt.marshal24 = pt.endOfDay && p.endOfDay
+	if p.retainText {
+		pt.style, pt.styled = f.style(), true
+	}
+	return pt, nil
+}
+
+// ParseString parses an ISO8601 compliant date-time string into a time.Time object.
+func ParseString(inp string) (Time, error) {
+	return Parse([]byte(inp))
+}
+
+// parse scans the whole input. If there is no zone designator, f.loc is left nil.
+func (f *fields) parse(inp []byte) error {
+	i, err := f.parseDate(inp, 0)
+	if err != nil {
+		return err
+	}
+
+	if i == len(inp) {
+		return nil
+	}
+
+	if inp[i] != 'T' {
+		return newUnexpectedCharacterError(rune(inp[i]))
+	}
+
+	if f.p < PrecisionDay {
+		return &SyntaxError{Value: string(inp), Element: "date", Rune: 'T', Reason: "a time requires a complete date"}
+	}
+
+	i, err = f.parseTime(inp, i+1)
+	if err != nil {
+		return err
+	}
+
+	if i == len(inp) {
+		return nil
+	}
+
+	return f.parseZone(inp, i)
+}
+
+// parseDate scans the date, which is either a calendar date YYYY-MM-DD (extended) or YYYYMMDD
+// (basic), a week date YYYY-Www-D (extended) or YYYYWwwD (basic), or an ordinal date
+// YYYY-DDD (extended) or YYYYDDD (basic). Reduced precision dates YYYY-MM, YYYY-Www, YYYY
+// and YY (century) are also allowed. The year may be an expanded year, i.e. a sign followed
+// by more digits than usual.
+// The date starts at inp[start]; parseDate returns the index of the first byte after it.
+func (f *fields) parseDate(inp []byte, start int) (int, error) {
+	f.p = PrecisionDay
+	f.M, f.d = 1, 1
+
+	// the year digits start at inp[i]; there are yd of them except in lenient extended format
+	i, yd := start, 4
+	if len(inp) > i && (inp[i] == '+' || inp[i] == '-') {
+		i, yd = i+1, 6
+		f.expanded = true
+		if f.yearDigits > 0 {
+			yd = 4 + f.yearDigits
+		}
+	}
+
+	j := scanDigits(inp, i)
+	n := j - i
+	if n == 0 {
+		if len(inp) == i {
+			return 0, &SyntaxError{Value: string(inp), Element: "date"}
+		}
+		return 0, newUnexpectedCharacterError(rune(inp[i]))
+	}
+
+	extended := j < len(inp) && inp[j] == '-'
+	if extended && i == start {
+		// years without a sign may have any number of digits in extended format
+		yd = n
+	}
+
+	switch {
+	case extended && n != yd, yd > maxDigits:
+		return 0, &SyntaxError{Value: string(inp), Element: "year", Reason: "wrong number of digits"}
+
+	case j == len(inp) && n == yd-2:
+		f.p = PrecisionCentury
+		return j, f.setYear(inp, i, n, 100)
+
+	case j == len(inp) && n == yd:
+		f.p = PrecisionYear
+		return j, f.setYear(inp, i, n, 1)
+
+	case n < yd:
+		return 0, &SyntaxError{Value: string(inp), Element: "date"}
+	}
+
+	if err := f.setYear(inp, i, yd, 1); err != nil {
+		return 0, err
+	}
+	i += yd
+
+	if extended {
+		if i+1 < len(inp) && inp[i+1] == 'W' {
+			return f.parseWeekDate(inp, i+2)
+		}
+
+		if k := scanDigits(inp, i+1); k-i == 4 {
+			f.form = ordinalDate
+			f.d = atoi(inp[i+1 : k])
+			return k, nil
+		}
+
+		var err error
+		j, f.M, err = f.scanField(inp, i+1, monthField)
+		if err != nil {
+			return 0, err
+		}
+
+		if j == len(inp) || inp[j] != '-' {
+			f.p = PrecisionMonth
+			return j, nil
+		}
+
+		j, f.d, err = f.scanField(inp, j+1, dayField)
+		return j, err
+	}
+
+	f.basic = true
+
+	switch j - i {
+	case 0:
+		if j < len(inp) && inp[j] == 'W' {
+			return f.parseWeekDate(inp, j+1)
+		}
+	case 3:
+		f.form = ordinalDate
+		f.d = atoi(inp[i:j])
+		return j, nil
+	case 4:
+		f.M = atoi(inp[i : i+2])
+		f.d = atoi(inp[i+2 : j])
+		return j, nil
+	}
+
+	return 0, &SyntaxError{Value: string(inp), Element: "date"}
+}
+
+// setYear sets the year from the n digits at inp[i], multiplied by scale. If the year
+// is preceded by a sign, it is an expanded year, and negative zero is not allowed.
+func (f *fields) setYear(inp []byte, i, n, scale int) error {
+	f.Y = atoi(inp[i:i+n]) * scale
+	if i > 0 && inp[i-1] == '-' {
+		if f.Y == 0 {
+			return &SyntaxError{Value: string(inp), Element: "year", Reason: "negative zero"}
+		}
+		f.Y = -f.Y
+	}
+	return nil
+}
+
+// parseWeekDate scans the week number and optional day of the week of a week date, starting
+// at inp[i] just after the 'W'. The day defaults to Monday if omitted.
+// It returns the index of the first byte after the date.
+func (f *fields) parseWeekDate(inp []byte, i int) (int, error) {
+	f.form = weekDate
+	f.wd = 1
+
+	j := i + 2
+	if scanDigits(inp, i) < j {
+		return 0, &SyntaxError{Value: string(inp), Element: "week"}
+	}
+	f.w = atoi(inp[i:j])
+
+	if j == len(inp) {
+		f.p = PrecisionWeek
+		return j, nil
+	}
+
+	switch {
+	case inp[j] == '-' && !f.basic:
+		j++
+	case '0' <= inp[j] && inp[j] <= '9' && f.basic:
+	case inp[j] == '-' || '0' <= inp[j] && inp[j] <= '9':
+		return 0, &SyntaxError{Value: string(inp), Element: "week day", Rune: rune(inp[j]), Reason: "basic and extended formats are mixed"}
+	default:
+		f.p = PrecisionWeek
+		return j, nil
+	}
+
+	k := scanDigits(inp, j)
+	if k-j != 1 {
+		return 0, &SyntaxError{Value: string(inp), Element: "week day"}
+	}
+	f.wd = atoi(inp[j:k])
+	return k, nil
+}
+
+// parseTime scans the time of day, which is either hh:mm:ss.sss (extended) or hhmmss.sss (basic),
+// starting at inp[i]. Lower-order components may be omitted, and the lowest-order component
+// present may have a decimal fraction. It returns the index of the first byte after the time.
+func (f *fields) parseTime(inp []byte, i int) (int, error) {
+	j := scanDigits(inp, i)
+	n := j - i
+
+	switch {
+	case n == 0:
+		return j, nil
+
+	case n <= 2:
+		// hh or the start of hh:mm:ss; an hour on its own is valid in either format
+		f.p = PrecisionHour
+		f.h = atoi(inp[i:j])
+		f.widths[hourField] = uint8(n)
+		if j == len(inp) || inp[j] != ':' {
+			return f.parseFraction(inp, j, time.Hour)
+		}
+
+		if f.basic && !f.mixed {
+			return 0, errMixedFormats(inp, ':')
+		}
+
+		var err error
+		f.p = PrecisionMinute
+		j, f.m, err = f.scanField(inp, j+1, minuteField)
+		if err != nil {
+			return 0, err
+		}
+
+		if j == len(inp) || inp[j] != ':' {
+			return f.parseFraction(inp, j, time.Minute)
+		}
+
+		f.p = PrecisionSecond
+		j, f.s, err = f.scanField(inp, j+1, secondField)
+		if err != nil {
+			return 0, err
+		}
+
+	case n == 4 || n == 6:
+		if !f.basic && !f.mixed {
+			return 0, errMixedFormats(inp, 0)
+		}
+
+		f.p = PrecisionMinute
+		f.h = atoi(inp[i : i+2])
+		f.m = atoi(inp[i+2 : i+4])
+		if n == 4 {
+			return f.parseFraction(inp, j, time.Minute)
 		}
+		f.p = PrecisionSecond
+		f.s = atoi(inp[i+4 : i+6])
+
+	default:
+		return 0, &SyntaxError{Value: string(inp), Element: "time"}
+	}
+
+	return f.parseFraction(inp, j, time.Second)
+}
+
+// parseFraction scans the optional decimal fraction starting at inp[i], which begins with
+// a full stop or a comma. The fraction applies to
+// the lowest-order time component present, whose duration is unit, and its value is carried down
+// into the lower-order components. It returns the index of the first byte after the fraction.
+//
+// The conversion is exact: there can be at most maxDigits decimal places and every such fraction
+// of an hour, minute or second is a whole number of nanoseconds, so no rounding is needed.
+// If f.truncate is set, any decimal places beyond the limit are dropped.
+func (f *fields) parseFraction(inp []byte, i int, unit time.Duration) (int, error) {
+	if i == len(inp) || (inp[i] != '.' && inp[i] != ',') {
+		return i, nil
 	}
 
-	// Capture remaining data
-	// Sometimes a date can end without a non-integer character
-	if c > 0 {
-		switch p {
-		case day:
-			d = c
-		case hour:
-			h = c
-		case minute:
-			m = c
-		case second:
-			s = c
-		case millisecond:
-			fraction = c
+	j := scanDigits(inp, i+1)
+	n := j - (i + 1)
+	if limit := f.maxDecimals(); n > limit {
+		switch {
+		case f.truncate:
+			n = limit
+		case n > maxDigits:
+			return 0, ErrPrecision
+		default:
+			return 0, &SyntaxError{Value: string(inp), Element: "fraction", Reason: "at most " + strconv.Itoa(limit) + " decimal places are allowed"}
 		}
 	}
 
-	// Get the seconds fraction as nanoseconds
-	if fraction < 0 || 1e9 <= fraction {
-		return Time{}, ErrPrecision
+	f.fractionOf, f.fractionDigits, f.decimal = f.p, n, inp[i]
+	f.p = PrecisionFraction
+	d := time.Duration(atoi(inp[i+1:i+1+n])) * (unit / time.Duration(pow10(n)))
+	if unit == time.Second {
+		// the usual case, which cannot carry into the minutes or seconds
+		f.fraction = int(d)
+		return j, nil
+	}
+
+	f.m += int(d / time.Minute)
+	d %= time.Minute
+	f.s += int(d / time.Second)
+	f.fraction = int(d % time.Second)
+	return j, nil
+}
+
+// maxDecimals returns the maximum number of decimal places in a fraction.
+func (f *fields) maxDecimals() int {
+	if f.decimals > 0 {
+		return f.decimals
+	}
+	return maxDigits
+}
+
+// parseZone scans the zone designator, starting at inp[i], which must be the last part of the input.
+func (f *fields) parseZone(inp []byte, i int) (err error) {
+	f.zone = inp[i:]
+	switch inp[i] {
+	case 'Z':
+		if len(inp) != i+1 {
+			return ErrRemainingData
+		}
+		f.loc = time.UTC
+	case '+', '-':
+		if f.negZero && string(inp[i:]) == "-00:00" {
+			// RFC 3339 uses this to mean UTC, with the local offset unknown
+			f.loc = time.UTC
+			return nil
+		}
+		f.loc, err = ParseISOZone(inp[i:])
+	default:
+		err = newUnexpectedCharacterError(rune(inp[i]))
 	}
-	scale := 10 - nfraction
-	for i := 0; i < scale; i++ {
-		fraction *= 10
+	return err
+}
+
+// validate checks that every component is within its expected range.
+// Week dates and ordinal dates are converted to calendar dates along the way.
+func (f *fields) validate(inp []byte) error {
+	switch f.form {
+	case ordinalDate:
+		if f.d < 1 || f.d > daysInYear(f.Y) { // Day 1-365 or 366
+			return &RangeError{
+				Value:   string(inp),
+				Element: "ordinal day",
+				Given:   f.d,
+				Min:     1,
+				Max:     daysInYear(f.Y),
+			}
+		}
+		f.Y, f.M, f.d = fromOrdinalDate(f.Y, f.d)
+
+	case weekDate:
+		switch {
+		case f.w < 1 || f.w > weeksIn(f.Y): // Week 1-52 or 53
+			return &RangeError{
+				Value:   string(inp),
+				Element: "week",
+				Given:   f.w,
+				Min:     1,
+				Max:     weeksIn(f.Y),
+			}
+		case f.wd < 1 || f.wd > 7: // Monday-Sunday
+			return &RangeError{
+				Value:   string(inp),
+				Element: "week day",
+				Given:   f.wd,
+				Min:     1,
+				Max:     7,
+			}
+		}
+		f.Y, f.M, f.d = fromWeekDate(f.Y, f.w, f.wd)
 	}
 
 	switch {
-	case M < 1 || M > 12: // Month 1-12
-		return Time{}, &RangeError{
+	case f.M < 1 || f.M > 12: // Month 1-12
+		return &RangeError{
 			Value:   string(inp),
 			Element: "month",
-			Given:   M,
+			Given:   f.M,
 			Min:     1,
 			Max:     12,
 		}
-	case d < 1 || d > daysIn(time.Month(M), Y): // Day 1-daysIn(month, year)
-		return Time{}, &RangeError{
+	case f.d < 1 || f.d > daysIn(time.Month(f.M), f.Y): // Day 1-daysIn(month, year)
+		return &RangeError{
 			Value:   string(inp),
 			Element: "day",
-			Given:   d,
+			Given:   f.d,
 			Min:     1,
-			Max:     daysIn(time.Month(M), Y),
+			Max:     daysIn(time.Month(f.M), f.Y),
 		}
-	case h > 23: // Hour 0-23
-		return Time{}, &RangeError{
+	case f.h > 23 && !f.isEndOfDay(): // Hour 0-23, or 24:00 exactly
+		return &RangeError{
 			Value:   string(inp),
 			Element: "hour",
-			Given:   h,
+			Given:   f.h,
 			Min:     0,
 			Max:     23,
 		}
-	case m > 59: // Minute 0-59
-		return Time{}, &RangeError{
+	case f.m > 59: // Minute 0-59
+		return &RangeError{
 			Value:   string(inp),
 			Element: "minute",
-			Given:   m,
+			Given:   f.m,
 			Min:     0,
 			Max:     59,
 		}
-	case s > 59: // Second 0-59
-		return Time{}, &RangeError{
+	case f.s > 59: // Second 0-59
+		return &RangeError{
 			Value:   string(inp),
 			Element: "second",
-			Given:   s,
+			Given:   f.s,
 			Min:     0,
 			Max:     59,
 		}
 	}
+	return nil
+}
 
-	return Date(Y, time.Month(M), d, h, m, s, fraction, loc), nil
+// isEndOfDay reports whether the time is 24:00, which ISO-8601 allows to mean the end of
+// the day. Every lower-order component must be zero.
+func (f *fields) isEndOfDay() bool {
+	return f.h == 24 && f.m == 0 && f.s == 0 && f.fraction == 0
 }
 
-// ParseString parses an ISO8601 compliant date-time string into a time.Time object.
-func ParseString(inp string) (Time, error) {
-	return Parse([]byte(inp))
+// The fields of an extended-format date or time that Parse accepts with one or two digits.
+const (
+	monthField = iota
+	dayField
+	hourField
+	minuteField
+	secondField
+	numFields
+)
+
+var fieldNames = [numFields]string{"month", "day", "hour", "minute", "second"}
+
+// scanField scans a one- or two-digit field of an extended-format date or time, starting at inp[i],
+// and records its width. It returns the index of the first byte after the field, and the field's value.
+func (f *fields) scanField(inp []byte, i, field int) (int, int, error) {
+	j, v := i, 0
+	for ; j < len(inp) && isDigit(inp[j]); j++ {
+		if j-i == 2 {
+			return 0, 0, &SyntaxError{Value: string(inp), Element: fieldNames[field], Reason: "too many digits"}
+		}
+		v = v*10 + int(inp[j]) - charStart
+	}
+
+	if j == i {
+		return 0, 0, &SyntaxError{Value: string(inp), Element: fieldNames[field]}
+	}
+	f.widths[field] = uint8(j - i)
+	return j, v, nil
+}
+
+// scanDigits returns the index of the first non-digit byte at or after inp[i].
+func scanDigits(inp []byte, i int) int {
+	for i < len(inp) && '0' <= inp[i] && inp[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// atoi converts a run of ASCII digits to an int. The caller ensures that every byte is a digit
+// and that there are no more than maxDigits of them.
+func atoi(b []byte) (n int) {
+	for _, c := range b {
+		n = n*10 + int(c) - charStart
+	}
+	return n
+}
+
+func errMixedFormats(inp []byte, r rune) error {
+	return &SyntaxError{Value: string(inp), Element: "time", Rune: r, Reason: "basic and extended formats are mixed"}
 }
 
 // String renders the time in ISO-8601 format (using RFC3339Nano).
@@ -205,6 +205,211 @@ func TestParse_ok(t *testing.T) {
 			MilliSecond: 502,
 			Zone:        0,
 		},
+
+		// basic format
+		{
+			Using: "20170424T094134Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+			Zone: 0,
+		},
+		{
+			Using: "20170424T094134.502+0100",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+			MilliSecond: 502,
+			Zone:        1,
+		},
+		{
+			Using: "20170424T0941-0530",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41,
+			Zone: -5.5,
+		},
+		{
+			Using: "20170424T09+01",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9,
+			Zone: 1,
+		},
+		{
+			Using: "20170424",
+			Year:  2017, Month: 4, Day: 24,
+		},
+
+		// week dates
+		{
+			Using: "2017-W17-1",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "2017-W17",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "2017W171",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "2017-W17-1T09:41:34.502+01:00",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+			MilliSecond: 502,
+			Zone:        1,
+		},
+		{
+			Using: "2017W171T094134Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+		},
+		{
+			Using: "2015-W53-7",
+			Year:  2016, Month: 1, Day: 3,
+		},
+		{
+			Using: "2019-W01-1",
+			Year:  2018, Month: 12, Day: 31,
+		},
+
+		// ordinal dates
+		{
+			Using: "2017-114",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "2017114",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "2017-114T09:41Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41,
+		},
+		{
+			Using: "2017114T0941Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41,
+		},
+		{
+			Using: "2016-366",
+			Year:  2016, Month: 12, Day: 31,
+		},
+
+		// fractional hours and minutes
+		{
+			Using: "2017-04-24T09.5Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 30,
+		},
+		{
+			Using: "2017-04-24T09:41.25Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 15,
+		},
+		{
+			Using: "20170424T0941.0125+0100",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 0,
+			MilliSecond: 750,
+			Zone:        1,
+		},
+		{
+			Using: "20170424T09.000125",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 0, Second: 0,
+			MilliSecond: 450,
+		},
+		{
+			Using: "2017-04-24T23.999999999",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 23, Minute: 59, Second: 59,
+			MilliSecond: 999,
+		},
+
+		// decimal comma
+		{
+			Using: "2017-04-24T09:41:34,502Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+			MilliSecond: 502,
+		},
+		{
+			Using: "20170424T094134,502+0100",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+			MilliSecond: 502,
+			Zone:        1,
+		},
+		{
+			Using: "2017-04-24T09,5Z",
+			Year:  2017, Month: 4, Day: 24,
+			Hour: 9, Minute: 30,
+		},
+
+		// expanded years
+		{
+			Using: "+002017-04-24",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "-000044-03-15T12:00Z",
+			Year:  -44, Month: 3, Day: 15,
+			Hour: 12,
+		},
+		{
+			Using: "+0123450424T094134Z",
+			Year:  12345, Month: 4, Day: 24,
+			Hour: 9, Minute: 41, Second: 34,
+		},
+		{
+			Using: "+012345-114",
+			Year:  12345, Month: 4, Day: 24,
+		},
+		{
+			Using: "+002017-W17-1",
+			Year:  2017, Month: 4, Day: 24,
+		},
+		{
+			Using: "+002017",
+			Year:  2017, Month: 1, Day: 1,
+		},
+		{
+			Using: "-0001",
+			Year:  -100, Month: 1, Day: 1,
+		},
+
+		// reduced precision
+		{
+			Using: "2017-04",
+			Year:  2017, Month: 4, Day: 1,
+		},
+		{
+			Using: "2017",
+			Year:  2017, Month: 1, Day: 1,
+		},
+		{
+			Using: "20",
+			Year:  2000, Month: 1, Day: 1,
+		},
+
+		// end of day
+		{
+			Using: "2017-04-24T24:00",
+			Year:  2017, Month: 4, Day: 25,
+		},
+		{
+			Using: "2017-04-24T24:00:00Z",
+			Year:  2017, Month: 4, Day: 25,
+		},
+		{
+			Using: "2017-12-31T24:00:00.0+01:00",
+			Year:  2018, Month: 1, Day: 1,
+			Zone: 1,
+		},
+		{
+			Using: "20170228T240000",
+			Year:  2017, Month: 3, Day: 1,
+		},
 	}
 
 	for _, c := range goodCases {
@@ -224,6 +429,9 @@ func TestParse_ok(t *testing.T) {
 
 			_, z := d.Zone()
 			expect.Number(float64(z)/3600).ToBe(t, c.Zone)
+
+			// Parse has a fast path that must agree with the zero Parser
+			expect.Any(Parser{}.ParseString(c.Using)).ToBe(t, d)
 		})
 	}
 }
@@ -249,6 +457,75 @@ func TestParse_error(t *testing.T) {
 			Message: `Cannot parse "-00:00": invalid zone`,
 		},
 
+		{
+			Using:   "20170424T09:41:34Z",
+			Message: `Cannot parse "20170424T09:41:34Z": invalid time at ':'; basic and extended formats are mixed`,
+		},
+		{
+			Using:   "2017-04-24T094134Z",
+			Message: `Cannot parse "2017-04-24T094134Z": invalid time; basic and extended formats are mixed`,
+		},
+		{
+			Using:   "2017-04T09:41Z",
+			Message: `Cannot parse "2017-04T09:41Z": invalid date at 'T'; a time requires a complete date`,
+		},
+		{
+			Using:   "2017-W17T09:41Z",
+			Message: `Cannot parse "2017-W17T09:41Z": invalid date at 'T'; a time requires a complete date`,
+		},
+		{
+			Using:   "201",
+			Message: `Cannot parse "201": invalid date`,
+		},
+		{
+			Using:   "2017-04-24T09.1234567891Z",
+			Message: `Too many characters in decimal fraction precision`,
+		},
+		{
+			Using:   "2017-04-24T09.5:30Z",
+			Message: "Unexpected character `:`",
+		},
+		{
+			Using:   "+2017-04-24",
+			Message: `Cannot parse "+2017-04-24": invalid year; wrong number of digits`,
+		},
+		{
+			Using:   "-000000-04-24",
+			Message: `Cannot parse "-000000-04-24": invalid year; negative zero`,
+		},
+		{
+			Using:   "+00201704",
+			Message: `Cannot parse "+00201704": invalid date`,
+		},
+		{
+			Using:   "2017-0424",
+			Message: `Cannot parse "2017-0424": invalid month; too many digits`,
+		},
+		{
+			Using:   "2017-04-24T09:Z",
+			Message: `Cannot parse "2017-04-24T09:Z": invalid minute`,
+		},
+		{
+			Using:   "201704",
+			Message: `Cannot parse "201704": invalid date`,
+		},
+		{
+			Using:   "2017-W171",
+			Message: `Cannot parse "2017-W171": invalid week day at '1'; basic and extended formats are mixed`,
+		},
+		{
+			Using:   "2017W17-1",
+			Message: `Cannot parse "2017W17-1": invalid week day at '-'; basic and extended formats are mixed`,
+		},
+		{
+			Using:   "2017-W7",
+			Message: `Cannot parse "2017-W7": invalid week`,
+		},
+		{
+			Using:   "20170424T09413",
+			Message: `Cannot parse "20170424T09413": invalid time`,
+		},
+
 		// Invalid Range Test Cases
 		{
 			Using:   "2017-00-01T00:00:00.000+00:00",
@@ -277,8 +554,54 @@ func TestParse_error(t *testing.T) {
 		},
 
 		{
-			Using:   "2017-01-01T24:00:00.000+00:00",
-			Message: `Cannot parse "2017-01-01T24:00:00.000+00:00": hour 24 is not in range 0-23`,
+			Using:   "2017-W53-1",
+			Message: `Cannot parse "2017-W53-1": week 53 is not in range 1-52`,
+		},
+		{
+			Using:   "2017-W00",
+			Message: `Cannot parse "2017-W00": week 0 is not in range 1-52`,
+		},
+		{
+			Using:   "2017-W17-8",
+			Message: `Cannot parse "2017-W17-8": week day 8 is not in range 1-7`,
+		},
+
+		{
+			Using:   "2017-000",
+			Message: `Cannot parse "2017-000": ordinal day 0 is not in range 1-365`,
+		},
+		{
+			Using:   "2017-366",
+			Message: `Cannot parse "2017-366": ordinal day 366 is not in range 1-365`,
+		},
+		{
+			Using:   "2016367T0941Z",
+			Message: `Cannot parse "2016367T0941Z": ordinal day 367 is not in range 1-366`,
+		},
+		{
+			// a basic ordinal date with an extended time needs a lenient Parser
+			Using:   "2017114T09:41Z",
+			Message: `Cannot parse "2017114T09:41Z": invalid time at ':'; basic and extended formats are mixed`,
+		},
+
+		{
+			Using:   "2017-01-01T24:00:00.001+00:00",
+			Message: `Cannot parse "2017-01-01T24:00:00.001+00:00": hour 24 is not in range 0-23`,
+		},
+
+		{
+			Using:   "2017-01-01T24:01",
+			Message: `Cannot parse "2017-01-01T24:01": hour 24 is not in range 0-23`,
+		},
+
+		{
+			Using:   "2017-01-01T24:00:01Z",
+			Message: `Cannot parse "2017-01-01T24:00:01Z": hour 24 is not in range 0-23`,
+		},
+
+		{
+			Using:   "2017-01-01T25:00:00Z",
+			Message: `Cannot parse "2017-01-01T25:00:00Z": hour 25 is not in range 0-23`,
 		},
 
 		{
@@ -302,6 +625,9 @@ func TestParse_error(t *testing.T) {
 					t.Errorf("Expected error message %q to contain %q", err.Error(), c.Message)
 				}
 			}
+
+			_, perr := Parser{}.ParseString(c.Using)
+			expect.Any(perr).ToBe(t, err)
 		})
 	}
 }
@@ -316,6 +642,16 @@ func BenchmarkParse(b *testing.B) {
 	}
 }
 
+func BenchmarkParse_basic(b *testing.B) {
+	x := []byte("20170424T094134.502Z")
+	for i := 0; i < b.N; i++ {
+		_, err := Parse(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestParseISOZone(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		expect.Any(ParseISOZone([]byte("Z"))).ToBe(t, time.UTC)
@@ -346,3 +682,66 @@ func TestParseISOZone(t *testing.T) {
 		expect.Error(ParseISOZone([]byte{0xAA, 0xBB})).ToContain(t, `iso8601: Cannot parse "\xaa\xbb": invalid zone at '?'`)
 	})
 }
+
+func TestParse_fractionIsExact(t *testing.T) {
+	cases := map[string]time.Duration{
+		"2017-04-24T00.000000001Z":       3600 * time.Nanosecond,
+		"2017-04-24T00:00.000000001Z":    60 * time.Nanosecond,
+		"2017-04-24T00:00:00.000000001Z": time.Nanosecond,
+		"2017-04-24T00.1Z":               6 * time.Minute,
+		"2017-04-24T00:00.1Z":            6 * time.Second,
+		"2017-04-24T00.333333333Z":       19*time.Minute + 59*time.Second + 999998800*time.Nanosecond,
+	}
+
+	midnight := Date(2017, 4, 24, 0, 0, 0, 0, time.UTC)
+	for inp, d := range cases {
+		t.Run(inp, func(t *testing.T) {
+			tm, err := ParseString(inp)
+			expect.Any(tm.Time.Sub(midnight.Time), err).ToBe(t, d)
+		})
+	}
+}
+
+func TestParse_expandedYearDigits(t *testing.T) {
+	p := Parser{}.WithExpandedYearDigits(3)
+
+	tm, err := p.ParseString("+0002017-04-24")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 0, 0, 0, 0, time.UTC))
+
+	tm, err = p.ParseString("-00020170424")
+	expect.Any(tm, err).ToBe(t, Date(-2017, 4, 24, 0, 0, 0, 0, time.UTC))
+
+	_, err = p.ParseString("+002017-04-24")
+	expect.Error(err).ToContain(t, "invalid year; wrong number of digits")
+
+	_, err = ParseString("+0002017-04-24")
+	expect.Error(err).ToContain(t, "invalid year; wrong number of digits")
+}
+
+func TestParseInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	expect.Error(err).ToBeNil(t)
+
+	cases := map[string]Time{
+		"2017-04-24T09:41:34":       Date(2017, 4, 24, 9, 41, 34, 0, berlin),
+		"2017-01-24T09:41":          Date(2017, 1, 24, 9, 41, 0, 0, berlin),
+		"2017-04-24":                Date(2017, 4, 24, 0, 0, 0, 0, berlin),
+		"2017-04-24T09:41:34Z":      Date(2017, 4, 24, 9, 41, 34, 0, time.UTC),
+		"2017-04-24T09:41:34+05:00": Date(2017, 4, 24, 9, 41, 34, 0, time.FixedZone("", 5*3600)),
+	}
+
+	for inp, expected := range cases {
+		t.Run(inp, func(t *testing.T) {
+			tm, err := ParseInLocation([]byte(inp), berlin)
+			expect.Any(tm, err).ToBe(t, expected)
+		})
+	}
+
+	tm, err := ParseInLocation([]byte("2017-04-24T09:41:34+05:00"), berlin)
+	expect.Error(err).ToBeNil(t)
+	_, offset := tm.Zone()
+	expect.Number(offset).ToBe(t, 5*3600)
+
+	_, err = ParseInLocation([]byte("2017-04-24T25:00"), berlin)
+	expect.Error(err).ToContain(t, "hour")
+}
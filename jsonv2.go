@@ -0,0 +1,129 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package iso8601
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+// This file implements the streaming methods of encoding/json/v2. They are built with Go 1.27
+// or later, which enables the jsonv2 experiment by default (GOEXPERIMENT=nojsonv2 disables it).
+// They produce the same JSON as MarshalJSON and UnmarshalJSON, but write to the encoder's
+// buffer and read from the decoder's buffer directly. The go1.27 constraint also allows this
+// file to use the json/v2 API, although go.mod specifies an earlier version.
+//
+// Note that encoding/json/v2 rejects the `format` tag option (e.g. `format:unixmilli`) on
+// struct fields, and has no way to pass it to these methods. Use TimeSecond, TimeMilli,
+// TimeMicro or TimeNano to choose the number of decimal places for a field.
+
+var (
+	_ json.MarshalerTo     = Time{}
+	_ json.UnmarshalerFrom = &Time{}
+	_ json.MarshalerTo     = PreciseTime{}
+	_ json.UnmarshalerFrom = &PreciseTime{}
+	_ json.MarshalerTo     = TimeSecond{}
+	_ json.MarshalerTo     = TimeMilli{}
+	_ json.MarshalerTo     = TimeMicro{}
+	_ json.MarshalerTo     = TimeNano{}
+)
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+// The time is rendered as for MarshalJSON.
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeText(enc, PreciseTime{Time: t}, "Time.MarshalJSONTo")
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface of encoding/json/v2.
+// It decodes a JSON string or null into a iso8601 time, as for UnmarshalJSON. The string
+// is parsed in place, unless it contains escape sequences.
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	b, err := readString(dec)
+	if b == nil || err != nil {
+		return err
+	}
+	*t, err = Parse(b)
+	return err
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+// The time is rendered as for MarshalJSON.
+func (t PreciseTime) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeText(enc, t, "PreciseTime.MarshalJSONTo")
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface of encoding/json/v2.
+// It decodes a JSON string or null, as for UnmarshalJSON.
+func (t *PreciseTime) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	b, err := readString(dec)
+	if b == nil || err != nil {
+		return err
+	}
+	*t, err = retainingParser().ParseWithPrecision(b)
+	return err
+}
+
+// writeText writes t to the encoder as a JSON string, as MarshalJSON would.
+func writeText(enc *jsontext.Encoder, t PreciseTime, method string) error {
+	b := append(enc.AvailableBuffer(), '"')
+	b, ok := t.appendText(b)
+	if !ok {
+		return errYearRange(method, t.Year())
+	}
+	b = append(b, '"')
+	return enc.WriteValue(b)
+}
+
+// readString reads a JSON string from the decoder and returns its content, which is in the
+// decoder's buffer unless the string contains escape sequences. It returns nil for a JSON null.
+func readString(dec *jsontext.Decoder) ([]byte, error) {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return nil, err
+	}
+
+	switch val.Kind() {
+	case 'n':
+		// Do not process null types
+		return nil, nil
+	case '"':
+	default:
+		return nil, ErrNotString
+	}
+
+	b := val[1 : len(val)-1]
+	if bytes.IndexByte(b, '\\') >= 0 {
+		return jsontext.AppendUnquote(nil, val)
+	}
+	return b, nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+func (t TimeSecond) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeFixed(enc, t.Time, RFC3339, "TimeSecond.MarshalJSONTo")
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+func (t TimeMilli) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeFixed(enc, t.Time, rfc3339FixedMilli, "TimeMilli.MarshalJSONTo")
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+func (t TimeMicro) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeFixed(enc, t.Time, rfc3339FixedMicro, "TimeMicro.MarshalJSONTo")
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+func (t TimeNano) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeFixed(enc, t.Time, rfc3339FixedNano, "TimeNano.MarshalJSONTo")
+}
+
+// writeFixed writes t to the encoder using the layout; see marshalFixed.
+func writeFixed(enc *jsontext.Encoder, t Time, layout string, method string) error {
+	b, err := appendFixed(enc.AvailableBuffer(), t.Time, layout, true, method)
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
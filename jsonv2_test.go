@@ -0,0 +1,78 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package iso8601
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestTime_JSONv2(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+	tm := Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1)
+
+	type record struct {
+		T Time      `json:"t"`
+		L TimeMilli `json:"l"`
+		P *Time     `json:"p"`
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(record{T: tm, L: TimeMilli{tm.Truncate(time.Second)}})
+		expect.String(b, err).ToEqual(t, `{"t":"2017-04-24T09:41:34.502+01:00","l":"2017-04-24T09:41:34.000+01:00","p":null}`)
+
+		_, err = json.Marshal(Date(-44, 3, 15, 0, 0, 0, 0, time.UTC))
+		expect.Error(err).ToContain(t, "Time.MarshalJSONTo: year -44 is outside the range 0-9999")
+		_, err = json.Marshal(TimeNano{Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)})
+		expect.Error(err).ToContain(t, "TimeNano.MarshalJSONTo: year 10000 is outside the range 0-9999")
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var r record
+		err := json.Unmarshal([]byte(`{"t":"2017-04-24T09:41:34.502+01:00","l":"2017-04-24T09:41:34.502+01:00","p":"2017-04-24T08:41:34.502Z"}`), &r)
+		expect.Error(err).ToBeNil(t)
+		expect.Any(r.T, err).ToBe(t, tm)
+		expect.Any(r.L.Time).ToBe(t, tm)
+		expect.Bool(r.P.Equal(tm)).ToBeTrue(t)
+
+		// escape sequences are unquoted
+		r = record{}
+		err = json.Unmarshal([]byte(`{"t":"2017-04-24T09:41:34.502\u002B01:00"}`), &r)
+		expect.Any(r.T, err).ToBe(t, tm)
+
+		// null leaves the time unchanged
+		err = json.Unmarshal([]byte(`{"t":null,"p":null}`), &r)
+		expect.Error(err).ToBeNil(t)
+		expect.Any(r.T).ToBe(t, tm)
+		expect.Any(r.P).ToBe(t, nil)
+
+		err = json.Unmarshal([]byte(`{"t":1492980094}`), &r)
+		expect.Bool(errors.Is(err, ErrNotString)).ToBeTrue(t)
+
+		err = json.Unmarshal([]byte(`{"t":"2017-04-31T09:41:34Z"}`), &r)
+		expect.Error(err).ToContain(t, "day 31")
+	})
+}
+
+func TestPreciseTime_JSONv2(t *testing.T) {
+	var r struct {
+		P PreciseTime `json:"p"`
+		T Time        `json:"t"`
+	}
+
+	err := json.Unmarshal([]byte(`{"p":"2017-04","t":"2017-04"}`), &r)
+	expect.Any(r.P.Precision(), err).ToBe(t, PrecisionMonth)
+
+	b, err := json.Marshal(r)
+	expect.String(b, err).ToEqual(t, `{"p":"2017-04","t":"2017-04-01T00:00:00Z"}`)
+
+	_, err = json.Marshal(Date(-44, 3, 15, 0, 0, 0, 0, time.UTC).WithPrecision(PrecisionDay))
+	expect.Error(err).ToContain(t, "PreciseTime.MarshalJSONTo: year -44 is outside the range 0-9999")
+
+	b, err = json.Marshal(Date(-44, 3, 15, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}.WithPrecision(PrecisionDay)))
+	expect.String(b, err).ToEqual(t, `"-000044-03-15"`)
+}
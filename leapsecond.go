@@ -0,0 +1,88 @@
+package iso8601
+
+import (
+	"slices"
+	"time"
+)
+
+// LeapSecond is an entry in the leap second table. From the start of Date (in UTC), TAI
+// is ahead of UTC by TAIMinusUTC seconds. Every entry except the first follows a leap second,
+// 23:59:60 UTC at the end of the previous day.
+type LeapSecond struct {
+	Date        LocalDate
+	TAIMinusUTC int
+}
+
+// leapSeconds is the table of TAI-UTC offsets since UTC adopted leap seconds, as published
+// by the IERS in Bulletin C. All the leap seconds so far have been positive.
+var leapSeconds = []LeapSecond{
+	{NewLocalDate(1972, 1, 1), 10},
+	{NewLocalDate(1972, 7, 1), 11},
+	{NewLocalDate(1973, 1, 1), 12},
+	{NewLocalDate(1974, 1, 1), 13},
+	{NewLocalDate(1975, 1, 1), 14},
+	{NewLocalDate(1976, 1, 1), 15},
+	{NewLocalDate(1977, 1, 1), 16},
+	{NewLocalDate(1978, 1, 1), 17},
+	{NewLocalDate(1979, 1, 1), 18},
+	{NewLocalDate(1980, 1, 1), 19},
+	{NewLocalDate(1981, 7, 1), 20},
+	{NewLocalDate(1982, 7, 1), 21},
+	{NewLocalDate(1983, 7, 1), 22},
+	{NewLocalDate(1985, 7, 1), 23},
+	{NewLocalDate(1988, 1, 1), 24},
+	{NewLocalDate(1990, 1, 1), 25},
+	{NewLocalDate(1991, 1, 1), 26},
+	{NewLocalDate(1992, 7, 1), 27},
+	{NewLocalDate(1993, 7, 1), 28},
+	{NewLocalDate(1994, 7, 1), 29},
+	{NewLocalDate(1996, 1, 1), 30},
+	{NewLocalDate(1997, 7, 1), 31},
+	{NewLocalDate(1999, 1, 1), 32},
+	{NewLocalDate(2006, 1, 1), 33},
+	{NewLocalDate(2009, 1, 1), 34},
+	{NewLocalDate(2012, 7, 1), 35},
+	{NewLocalDate(2015, 7, 1), 36},
+	{NewLocalDate(2017, 1, 1), 37},
+}
+
+// LeapSeconds returns a copy of the leap second table, in chronological order.
+func LeapSeconds() []LeapSecond {
+	return slices.Clone(leapSeconds)
+}
+
+// TAIMinusUTC returns the number of seconds by which TAI is ahead of UTC at the instant t.
+// It returns false if t is before 1972, when UTC was not an integral number of seconds
+// behind TAI.
+func TAIMinusUTC(t time.Time) (int, bool) {
+	d := LocalDateOf(t.UTC())
+	i, found := slices.BinarySearchFunc(leapSeconds, d, func(ls LeapSecond, d LocalDate) int {
+		return ls.Date.Compare(d)
+	})
+	if !found {
+		// i is the index of the first entry after d
+		if i == 0 {
+			return 0, false
+		}
+		i--
+	}
+	return leapSeconds[i].TAIMinusUTC, true
+}
+
+// isLeapSecond reports whether the second before t, which is in UTC, is a leap second.
+func isLeapSecond(t time.Time) bool {
+	if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
+		return false
+	}
+	d := LocalDateOf(t)
+	_, found := slices.BinarySearchFunc(leapSeconds[1:], d, func(ls LeapSecond, d LocalDate) int {
+		return ls.Date.Compare(d)
+	})
+	return found
+}
+
+// IsLeapSecond reports whether t was parsed from a leap second, i.e. second 60; see
+// Parser.WithLeapSeconds.
+func (t PreciseTime) IsLeapSecond() bool {
+	return t.leapSecond
+}
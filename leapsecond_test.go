@@ -0,0 +1,88 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParse_leapSecond(t *testing.T) {
+	_, err := ParseString("2016-12-31T23:59:60Z")
+	expect.Error(err).ToContain(t, "second 60 is not in range 0-59")
+
+	p := Parser{}.WithLeapSeconds()
+
+	cases := map[string]Time{
+		"2016-12-31T23:59:60Z":           Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2016-12-31T23:59:60.5Z":         Date(2017, 1, 1, 0, 0, 0, 500_000_000, time.UTC),
+		"2015-06-30T23:59:60":            Date(2015, 7, 1, 0, 0, 0, 0, time.UTC),
+		"2017-01-01T08:59:60+09:00":      Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2016-12-31T18:59:60-05:00":      Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		"1972-06-30T23:59:60.999999999Z": Date(1972, 7, 1, 0, 0, 0, 999_999_999, time.UTC),
+	}
+
+	for inp, expected := range cases {
+		t.Run(inp, func(t *testing.T) {
+			tm, err := p.ParseWithPrecision([]byte(inp))
+			expect.Any(tm.Time, err).ToBe(t, expected)
+			expect.Bool(tm.IsLeapSecond()).ToBeTrue(t)
+		})
+	}
+
+	errorCases := []string{
+		"2016-12-31T23:58:60Z",
+		"2016-12-30T23:59:60Z",
+		"2016-06-30T23:59:60Z",
+		"2016-12-31T23:59:60+01:00",
+		"1971-12-31T23:59:60Z",
+		"2016-12-31T23:59:61Z",
+	}
+
+	for _, inp := range errorCases {
+		t.Run(inp, func(t *testing.T) {
+			_, err := p.ParseString(inp)
+			expect.Error(err).ToContain(t, "second")
+		})
+	}
+
+	tm, err := p.ParseWithPrecision([]byte("2016-12-31T23:59:59Z"))
+	expect.Error(err).ToBeNil(t)
+	expect.Bool(tm.IsLeapSecond()).ToBeFalse(t)
+}
+
+func TestLeapSeconds(t *testing.T) {
+	table := LeapSeconds()
+	expect.Slice(table).ToHaveLength(t, 28)
+	expect.Any(table[0]).ToBe(t, LeapSecond{Date: NewLocalDate(1972, 1, 1), TAIMinusUTC: 10})
+	expect.Any(table[27]).ToBe(t, LeapSecond{Date: NewLocalDate(2017, 1, 1), TAIMinusUTC: 37})
+
+	for i := 1; i < len(table); i++ {
+		expect.Number(table[i].TAIMinusUTC).ToBe(t, table[i-1].TAIMinusUTC+1)
+	}
+
+	// the table is a copy
+	table[0].TAIMinusUTC = 0
+	expect.Number(LeapSeconds()[0].TAIMinusUTC).ToBe(t, 10)
+}
+
+func TestTAIMinusUTC(t *testing.T) {
+	cases := []struct {
+		t        time.Time
+		expected int
+		ok       bool
+	}{
+		{time.Date(1971, 12, 31, 23, 59, 59, 0, time.UTC), 0, false},
+		{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10, true},
+		{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 36, true},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37, true},
+		{time.Date(2017, 1, 1, 8, 0, 0, 0, time.FixedZone("", 9*3600)), 36, true},
+		{time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), 37, true},
+	}
+
+	for _, c := range cases {
+		n, ok := TAIMinusUTC(c.t)
+		expect.Number(n).ToBe(t, c.expected)
+		expect.Bool(ok).ToBe(t, c.ok)
+	}
+}
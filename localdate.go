@@ -0,0 +1,262 @@
+package iso8601
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LocalDate is an ISO-8601 calendar date, i.e. a year, month and day without a time of day
+// or a zone. It is suitable for values such as birthdays and invoice dates, which are not
+// instants. It marshals as YYYY-MM-DD.
+//
+// The zero value is January 1, year 1.
+type LocalDate struct {
+	t time.Time // midnight UTC at the start of the date
+}
+
+var (
+	_ json.Unmarshaler = &LocalDate{}
+	_ sql.Scanner      = &LocalDate{}
+	_ driver.Valuer    = LocalDate{}
+)
+
+// NewLocalDate returns the LocalDate for the given year, month and day. The month and
+// day may be outside their usual ranges and will be normalized as by time.Date.
+func NewLocalDate(year int, month time.Month, day int) LocalDate {
+	return LocalDate{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// LocalDateOf returns the date on which t occurs, as seen in its location.
+func LocalDateOf(t time.Time) LocalDate {
+	return NewLocalDate(t.Date())
+}
+
+// ParseLocalDate parses an ISO-8601 date, which can be a calendar date (e.g. 2017-04-24),
+// a week date (e.g. 2017-W17-1) or an ordinal date (e.g. 2017-114), in extended or basic
+// format. The date must be complete and must not be followed by a time.
+func ParseLocalDate(inp []byte) (LocalDate, error) {
+	return defaultParser().ParseLocalDate(inp)
+}
+
+// ParseLocalDateString parses an ISO-8601 date string; see ParseLocalDate.
+func ParseLocalDateString(inp string) (LocalDate, error) {
+	return ParseLocalDate([]byte(inp))
+}
+
+// ParseLocalDate is like the ParseLocalDate function but uses the parser's forms, expanded
+// year digits and white space setting. The profile does not apply, because it is for date-times.
+func (p Parser) ParseLocalDate(inp []byte) (LocalDate, error) {
+	b := p.trim(inp)
+	d, err := p.parseLocalDate(b)
+	if err != nil {
+		return LocalDate{}, withValue(err, b, inp)
+	}
+	return d, nil
+}
+
+// parseLocalDate parses the date, once any white space has been trimmed.
+func (p Parser) parseLocalDate(inp []byte) (LocalDate, error) {
+	f := p.newFields()
+	j, err := f.parseDate(inp, 0)
+	if err != nil {
+		return LocalDate{}, err
+	}
+	if j < len(inp) {
+		return LocalDate{}, newUnexpectedCharacterError(rune(inp[j]))
+	}
+	if f.p < PrecisionDay {
+		return LocalDate{}, &SyntaxError{Value: string(inp), Element: "date", Reason: "a complete date is required"}
+	}
+	if err = p.checkForms(inp, &f); err != nil {
+		return LocalDate{}, err
+	}
+	if err = f.validate(inp); err != nil {
+		return LocalDate{}, err
+	}
+	return NewLocalDate(f.Y, time.Month(f.M), f.d), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// In returns the Time at midnight at the start of the date in the given location.
+// In panics if loc is nil.
+func (d LocalDate) In(loc *time.Location) Time {
+	return Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// Date returns the year, month and day.
+func (d LocalDate) Date() (year int, month time.Month, day int) {
+	return d.t.Date()
+}
+
+// Year returns the year.
+func (d LocalDate) Year() int {
+	return d.t.Year()
+}
+
+// Month returns the month of the year.
+func (d LocalDate) Month() time.Month {
+	return d.t.Month()
+}
+
+// Day returns the day of the month.
+func (d LocalDate) Day() int {
+	return d.t.Day()
+}
+
+// Weekday returns the day of the week.
+func (d LocalDate) Weekday() time.Weekday {
+	return d.t.Weekday()
+}
+
+// ISOWeek returns the ISO-8601 year and week number in which the date occurs.
+func (d LocalDate) ISOWeek() (year, week int) {
+	return d.t.ISOWeek()
+}
+
+// Week returns the ISO-8601 week in which the date occurs.
+func (d LocalDate) Week() Week {
+	return WeekOf(Of(d.t))
+}
+
+// YearDay returns the day of the year, i.e. the ordinal date, in the range [1,365]
+// for non-leap years and [1,366] in leap years.
+func (d LocalDate) YearDay() int {
+	return d.t.YearDay()
+}
+
+// AddDays returns the date n days after d; n may be negative.
+func (d LocalDate) AddDays(n int) LocalDate {
+	return LocalDate{t: d.t.AddDate(0, 0, n)}
+}
+
+// AddMonths returns the date n months after d; n may be negative. As for time.Time.AddDate,
+// the result is normalized, so adding one month to October 31 yields December 1.
+func (d LocalDate) AddMonths(n int) LocalDate {
+	return LocalDate{t: d.t.AddDate(0, n, 0)}
+}
+
+// Sub returns the number of days from u to d, which is negative if d is before u.
+func (d LocalDate) Sub(u LocalDate) int {
+	// both are midnight UTC, so the difference in Unix seconds is a whole number of days;
+	// unlike a time.Duration, it does not overflow for dates more than 292 years apart
+	return int((d.t.Unix() - u.t.Unix()) / 86400)
+}
+
+// IsZero reports whether d is the zero value, January 1, year 1.
+func (d LocalDate) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Before reports whether d is before u.
+func (d LocalDate) Before(u LocalDate) bool {
+	return d.t.Before(u.t)
+}
+
+// After reports whether d is after u.
+func (d LocalDate) After(u LocalDate) bool {
+	return d.t.After(u.t)
+}
+
+// Compare compares d with u. If d is before u, it returns -1; if d is after u,
+// it returns +1; if they're the same, it returns 0.
+func (d LocalDate) Compare(u LocalDate) int {
+	return d.t.Compare(u.t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the date in ISO-8601 extended format, YYYY-MM-DD.
+func (d LocalDate) String() string {
+	return string(d.appendTo(make([]byte, 0, 10)))
+}
+
+func (d LocalDate) appendTo(b []byte) []byte {
+	b = appendYear(b, d.Year(), 4)
+	b = append(b, '-')
+	b = appendInt(b, int(d.Month()), 2)
+	b = append(b, '-')
+	return appendInt(b, d.Day(), 2)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The date is formatted as YYYY-MM-DD. Years outside the range 0-9999 are an error.
+func (d LocalDate) MarshalText() ([]byte, error) {
+	if y := d.Year(); y < 0 || y >= 10000 {
+		return nil, errYearRange("LocalDate.MarshalText", y)
+	}
+	return d.appendTo(make([]byte, 0, 10)), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The date is a quoted string formatted as YYYY-MM-DD. Years outside the range 0-9999
+// are an error.
+func (d LocalDate) MarshalJSON() ([]byte, error) {
+	if y := d.Year(); y < 0 || y >= 10000 {
+		return nil, errYearRange("LocalDate.MarshalJSON", y)
+	}
+	b := make([]byte, 0, 12)
+	b = append(b, '"')
+	b = d.appendTo(b)
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The date is expected to be in ISO-8601 format; see ParseLocalDate.
+func (d *LocalDate) UnmarshalText(data []byte) (err error) {
+	*d, err = ParseLocalDate(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a date.
+func (d *LocalDate) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*d, err = ParseLocalDate(b)
+	return err
+}
+
+// Scan implements the sql.Scanner interface. It accepts a time.Time, which is typical of
+// DATE columns, or a string or []byte holding an ISO-8601 date or date-time. For a date-time,
+// the date as seen in its zone is used. A nil value gives the zero date.
+func (d *LocalDate) Scan(value any) (err error) {
+	switch v := value.(type) {
+	case nil:
+		*d = LocalDate{}
+	case time.Time:
+		*d = LocalDateOf(v)
+	case string:
+		*d, err = scanLocalDate([]byte(v))
+	case []byte:
+		*d, err = scanLocalDate(v)
+	default:
+		err = fmt.Errorf("LocalDate.Scan: unsupported type %T", value)
+	}
+	return err
+}
+
+// scanLocalDate parses a date, or else a date-time as Time.Scan does, such as the
+// 2017-04-24 09:41:34 that databases often produce.
+func scanLocalDate(b []byte) (LocalDate, error) {
+	d, err := ParseLocalDate(b)
+	if err != nil {
+		t, err2 := defaultParser().WithLenient().Parse(b)
+		if err2 != nil {
+			return LocalDate{}, err
+		}
+		d = LocalDateOf(t.Time)
+	}
+	return d, nil
+}
+
+// Value implements the driver.Valuer interface. The date is given as a time.Time
+// at midnight UTC.
+func (d LocalDate) Value() (driver.Value, error) {
+	return d.t, nil
+}
@@ -0,0 +1,170 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseLocalDate_ok(t *testing.T) {
+	cases := map[string]LocalDate{
+		"2017-04-24": NewLocalDate(2017, 4, 24),
+		"20170424":   NewLocalDate(2017, 4, 24),
+		"2017-W17-1": NewLocalDate(2017, 4, 24),
+		"2017W171":   NewLocalDate(2017, 4, 24),
+		"2017-114":   NewLocalDate(2017, 4, 24),
+		"2017114":    NewLocalDate(2017, 4, 24),
+		"2016-02-29": NewLocalDate(2016, 2, 29),
+	}
+
+	for inp, expected := range cases {
+		t.Run(inp, func(t *testing.T) {
+			d, err := ParseLocalDateString(inp)
+			expect.Any(d, err).ToBe(t, expected)
+		})
+	}
+}
+
+func TestParseLocalDate_error(t *testing.T) {
+	cases := map[string]string{
+		"":                     `Cannot parse "": invalid date`,
+		"2017-04":              `Cannot parse "2017-04": invalid date; a complete date is required`,
+		"2017-02-29":           `day`,
+		"2017-04-24T09:41:34Z": "Unexpected character `T`",
+		"2017-04-24Z":          "Unexpected character `Z`",
+	}
+
+	for inp, msg := range cases {
+		t.Run(inp, func(t *testing.T) {
+			_, err := ParseLocalDateString(inp)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestLocalDate_accessors(t *testing.T) {
+	d := NewLocalDate(2021, 1, 1)
+
+	y, m, day := d.Date()
+	expect.Number(y).ToBe(t, 2021)
+	expect.Number(m).ToBe(t, time.January)
+	expect.Number(day).ToBe(t, 1)
+	expect.Number(d.Year()).ToBe(t, 2021)
+	expect.Number(d.Month()).ToBe(t, time.January)
+	expect.Number(d.Day()).ToBe(t, 1)
+
+	expect.Number(d.Weekday()).ToBe(t, time.Friday)
+	wy, w := d.ISOWeek()
+	expect.Number(wy).ToBe(t, 2020)
+	expect.Number(w).ToBe(t, 53)
+	expect.Any(d.Week()).ToBe(t, Week{Year: 2020, Week: 53})
+	expect.Number(d.YearDay()).ToBe(t, 1)
+	expect.Number(NewLocalDate(2020, 12, 31).YearDay()).ToBe(t, 366)
+
+	expect.Bool(d.IsZero()).ToBeFalse(t)
+	expect.Bool(LocalDate{}.IsZero()).ToBeTrue(t)
+	expect.Bool(d.Before(d.AddDays(1))).ToBeTrue(t)
+	expect.Bool(d.After(d.AddDays(1))).ToBeFalse(t)
+	expect.Number(d.Compare(d)).ToBe(t, 0)
+}
+
+func TestLocalDate_arithmetic(t *testing.T) {
+	d := NewLocalDate(2017, 1, 31)
+
+	expect.Any(d.AddDays(1)).ToBe(t, NewLocalDate(2017, 2, 1))
+	expect.Any(d.AddDays(-31)).ToBe(t, NewLocalDate(2016, 12, 31))
+	expect.Any(d.AddMonths(2)).ToBe(t, NewLocalDate(2017, 3, 31))
+	expect.Any(d.AddMonths(1)).ToBe(t, NewLocalDate(2017, 3, 3))
+	expect.Any(d.AddMonths(-12)).ToBe(t, NewLocalDate(2016, 1, 31))
+
+	expect.Number(NewLocalDate(2017, 3, 1).Sub(d)).ToBe(t, 29)
+	expect.Number(d.Sub(NewLocalDate(2017, 3, 1))).ToBe(t, -29)
+	expect.Number(NewLocalDate(2018, 1, 31).Sub(d)).ToBe(t, 365)
+
+	// spans longer than a time.Duration can hold
+	first, last := NewLocalDate(1, 1, 1), NewLocalDate(9999, 12, 31)
+	expect.Number(last.Sub(first)).ToBe(t, 3_652_058)
+	expect.Number(first.Sub(last)).ToBe(t, -3_652_058)
+	expect.Any(first.AddDays(last.Sub(first))).ToBe(t, last)
+	expect.Number(NewLocalDate(2317, 1, 31).Sub(d)).ToBe(t, 109_572)
+}
+
+func TestLocalDate_conversion(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	d := NewLocalDate(2017, 4, 24)
+
+	expect.Any(d.In(tokyo)).ToBe(t, Date(2017, 4, 24, 0, 0, 0, 0, tokyo))
+	expect.Any(LocalDateOf(Date(2017, 4, 24, 23, 59, 0, 0, tokyo).Time)).ToBe(t, d)
+	expect.Any(LocalDateOf(Date(2017, 4, 24, 23, 59, 0, 0, tokyo).UTC().Time)).ToBe(t, d)
+	expect.Any(LocalDateOf(Date(2017, 4, 24, 1, 0, 0, 0, tokyo).UTC().Time)).ToBe(t, NewLocalDate(2017, 4, 23))
+}
+
+func TestLocalDate_Marshaling(t *testing.T) {
+	d := NewLocalDate(2017, 4, 24)
+	expect.String(d.String()).ToBe(t, "2017-04-24")
+
+	t.Run("text", func(t *testing.T) {
+		b, err := d.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24")
+
+		var d2 LocalDate
+		err = d2.UnmarshalText(b)
+		expect.Any(d2, err).ToBe(t, d)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(d)
+		expect.String(b, err).ToEqual(t, `"2017-04-24"`)
+
+		var d2 LocalDate
+		err = json.Unmarshal(b, &d2)
+		expect.Any(d2, err).ToBe(t, d)
+
+		expect.Any(d2.UnmarshalJSON([]byte(`2017`))).ToBe(t, ErrNotString)
+		expect.Error(d2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+
+	t.Run("year out of range", func(t *testing.T) {
+		_, err := NewLocalDate(-44, 3, 15).MarshalText()
+		expect.Error(err).ToContain(t, "LocalDate.MarshalText: year -44 is outside the range 0-9999")
+		_, err = NewLocalDate(12017, 4, 24).MarshalJSON()
+		expect.Error(err).ToContain(t, "LocalDate.MarshalJSON: year 12017 is outside the range 0-9999")
+
+		// String renders an expanded year because it cannot fail
+		expect.String(NewLocalDate(-44, 3, 15).String()).ToBe(t, "-000044-03-15")
+	})
+}
+
+func TestLocalDate_sql(t *testing.T) {
+	d := NewLocalDate(2017, 4, 24)
+
+	v, err := d.Value()
+	expect.Any(v, err).ToBe(t, time.Date(2017, 4, 24, 0, 0, 0, 0, time.UTC))
+
+	cases := []any{
+		time.Date(2017, 4, 24, 0, 0, 0, 0, time.UTC),
+		"2017-04-24",
+		[]byte("2017-04-24"),
+		"2017-04-24T00:00:00Z",
+		"2017-04-24 09:41:34",
+		[]byte("2017-04-24 09:41:34.502+01"),
+	}
+
+	for _, c := range cases {
+		var d2 LocalDate
+		err = d2.Scan(c)
+		expect.Any(d2, err).ToBe(t, d)
+	}
+
+	d2 := d
+	err = d2.Scan(nil)
+	expect.Any(d2, err).ToBe(t, LocalDate{})
+
+	err = d2.Scan(42)
+	expect.Error(err).ToContain(t, "LocalDate.Scan: unsupported type int")
+
+	err = d2.Scan("2017-04-31")
+	expect.Error(err).ToContain(t, "day")
+}
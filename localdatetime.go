@@ -0,0 +1,201 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// LocalDateTime is an ISO-8601 date-time without a zone designator, i.e. a local time in
+// ISO-8601 terms. It is a date and a time of day as read from a calendar and a clock, but it
+// is not an instant: it can only be converted to one by deciding which location it is in.
+//
+// The zero value is January 1, year 1, 00:00:00.
+type LocalDateTime struct {
+	t time.Time // the date and time of day, held in UTC for convenience
+}
+
+var _ json.Unmarshaler = &LocalDateTime{}
+
+// NewLocalDateTime returns the LocalDateTime corresponding to
+//
+//	yyyy-mm-dd hh:mm:ss + nsec nanoseconds
+//
+// The values may be outside their usual ranges and will be normalized as by time.Date.
+func NewLocalDateTime(year int, month time.Month, day, hour, min, sec, nsec int) LocalDateTime {
+	return LocalDateTime{t: time.Date(year, month, day, hour, min, sec, nsec, time.UTC)}
+}
+
+// LocalDateTimeOf returns the date and time of day of t, as seen in its location. The
+// location itself is discarded.
+func LocalDateTimeOf(t time.Time) LocalDateTime {
+	y, m, d := t.Date()
+	hh, mm, ss := t.Clock()
+	return NewLocalDateTime(y, m, d, hh, mm, ss, t.Nanosecond())
+}
+
+// ParseLocalDateTime parses an ISO-8601 date-time that has no zone designator. Any of the
+// forms accepted by Parse can be used. If there is a zone designator, an *iso8601.SyntaxError
+// is returned; use Parse or ParseInLocation for such inputs.
+func ParseLocalDateTime(inp []byte) (LocalDateTime, error) {
+	return defaultParser().ParseLocalDateTime(inp)
+}
+
+// ParseLocalDateTimeString parses an ISO-8601 date-time string that has no zone designator;
+// see ParseLocalDateTime.
+func ParseLocalDateTimeString(inp string) (LocalDateTime, error) {
+	return ParseLocalDateTime([]byte(inp))
+}
+
+// ParseLocalDateTime is like the ParseLocalDateTime function but uses the parser's settings,
+// other than its location and leap seconds. The profile must allow a date-time without a zone
+// designator, as ProfileISO8601 and ProfileXMLSchemaDateTime do.
+func (p Parser) ParseLocalDateTime(inp []byte) (LocalDateTime, error) {
+	b := p.trim(inp)
+	norm, err := p.normalize(b, -1)
+	if err != nil {
+		return LocalDateTime{}, withValue(err, b, inp)
+	}
+
+	l, err := p.parseLocalDateTime(norm)
+	if err != nil {
+		return LocalDateTime{}, withValue(err, norm, inp)
+	}
+	return l, nil
+}
+
+// parseLocalDateTime parses the date-time, once it has been trimmed and normalized.
+func (p Parser) parseLocalDateTime(inp []byte) (LocalDateTime, error) {
+	f := p.newFields()
+	if err := f.parse(inp); err != nil {
+		return LocalDateTime{}, err
+	}
+	if f.loc != nil {
+		return LocalDateTime{}, &SyntaxError{Value: string(inp), Element: "date-time", Reason: "a local date-time must not have a zone"}
+	}
+	if err := p.check(inp, &f); err != nil {
+		return LocalDateTime{}, err
+	}
+	if err := f.validate(inp); err != nil {
+		return LocalDateTime{}, err
+	}
+	return NewLocalDateTime(f.Y, time.Month(f.M), f.d, f.h, f.m, f.s, f.fraction), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// In returns the Time at which the date and time of day occur in the given location.
+//
+// A daylight savings time transition skips or repeats times, in which case the result is
+// correct in one of the two zones involved, as for time.Date.
+//
+// In panics if loc is nil.
+func (l LocalDateTime) In(loc *time.Location) Time {
+	y, m, d := l.t.Date()
+	hh, mm, ss := l.t.Clock()
+	return Date(y, m, d, hh, mm, ss, l.t.Nanosecond(), loc)
+}
+
+// Date returns the year, month and day.
+func (l LocalDateTime) Date() (year int, month time.Month, day int) {
+	return l.t.Date()
+}
+
+// Clock returns the hour, minute and second within the day.
+func (l LocalDateTime) Clock() (hour, min, sec int) {
+	return l.t.Clock()
+}
+
+// Nanosecond returns the nanosecond offset within the second, in the range [0, 999999999].
+func (l LocalDateTime) Nanosecond() int {
+	return l.t.Nanosecond()
+}
+
+// IsZero reports whether l is the zero value, January 1, year 1, 00:00:00.
+func (l LocalDateTime) IsZero() bool {
+	return l.t.IsZero()
+}
+
+// Before reports whether l is before u.
+func (l LocalDateTime) Before(u LocalDateTime) bool {
+	return l.t.Before(u.t)
+}
+
+// After reports whether l is after u.
+func (l LocalDateTime) After(u LocalDateTime) bool {
+	return l.t.After(u.t)
+}
+
+// Compare compares l with u. If l is before u, it returns -1; if l is after u,
+// it returns +1; if they're the same, it returns 0.
+func (l LocalDateTime) Compare(u LocalDateTime) int {
+	return l.t.Compare(u.t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the date-time in ISO-8601 format without a zone, e.g. 2017-04-24T09:41:34.502.
+func (l LocalDateTime) String() string {
+	return l.t.Format(localLayout(RFC3339Nano))
+}
+
+// appendText renders the date-time without a zone, with sub-second precision controlled by
+// MarshalTextFormat. It returns false if the year is outside the range 0-9999.
+func (l LocalDateTime) appendText(b []byte) ([]byte, bool) {
+	if y := l.t.Year(); y < 0 || y >= 10000 {
+		return nil, false
+	}
+	return l.t.AppendFormat(b, localLayout(MarshalTextFormat)), true
+}
+
+// localLayout removes the numeric zone element, such as Z07:00 or -0700, from a layout.
+func localLayout(layout string) string {
+	for _, z := range zoneLayouts {
+		if i := strings.Index(layout, z); i >= 0 {
+			return layout[:i] + layout[i+len(z):]
+		}
+	}
+	return layout
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The date-time is formatted in ISO-8601 format without a zone, with sub-second
+// precision controlled by MarshalTextFormat. Years outside the range 0-9999 are an error.
+func (l LocalDateTime) MarshalText() ([]byte, error) {
+	b, ok := l.appendText(make([]byte, 0, len(MarshalTextFormat)))
+	if !ok {
+		return nil, errYearRange("LocalDateTime.MarshalText", l.t.Year())
+	}
+	return b, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The date-time is a quoted string in ISO-8601 format without a zone, with sub-second
+// precision controlled by MarshalTextFormat.
+func (l LocalDateTime) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, len(MarshalTextFormat)+2)
+	b = append(b, '"')
+	b, ok := l.appendText(b)
+	if !ok {
+		return nil, errYearRange("LocalDateTime.MarshalJSON", l.t.Year())
+	}
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The date-time is expected to be in ISO-8601 format without a zone.
+func (l *LocalDateTime) UnmarshalText(data []byte) (err error) {
+	*l, err = ParseLocalDateTime(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a local date-time.
+func (l *LocalDateTime) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*l, err = ParseLocalDateTime(b)
+	return err
+}
@@ -0,0 +1,123 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseLocalDateTime_ok(t *testing.T) {
+	cases := map[string]LocalDateTime{
+		"2017-04-24T09:41:34.502": NewLocalDateTime(2017, 4, 24, 9, 41, 34, 502000000),
+		"20170424T094134":         NewLocalDateTime(2017, 4, 24, 9, 41, 34, 0),
+		"2017-04-24T09:41":        NewLocalDateTime(2017, 4, 24, 9, 41, 0, 0),
+		"2017-04-24":              NewLocalDateTime(2017, 4, 24, 0, 0, 0, 0),
+		"2017-W17-1T09:00":        NewLocalDateTime(2017, 4, 24, 9, 0, 0, 0),
+	}
+
+	for inp, expected := range cases {
+		t.Run(inp, func(t *testing.T) {
+			l, err := ParseLocalDateTimeString(inp)
+			expect.Any(l, err).ToBe(t, expected)
+		})
+	}
+}
+
+func TestParseLocalDateTime_error(t *testing.T) {
+	cases := map[string]string{
+		"2017-04-24T09:41:34Z":      `Cannot parse "2017-04-24T09:41:34Z": invalid date-time; a local date-time must not have a zone`,
+		"2017-04-24T09:41:34+01:00": `Cannot parse "2017-04-24T09:41:34+01:00": invalid date-time; a local date-time must not have a zone`,
+		"2017-04-31T09:41:34":       `day`,
+		"2017-04-24X":               `Unexpected character`,
+	}
+
+	for inp, msg := range cases {
+		t.Run(inp, func(t *testing.T) {
+			_, err := ParseLocalDateTimeString(inp)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestLocalDateTime_In(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	expect.Error(err).ToBeNil(t)
+
+	l := NewLocalDateTime(2017, 4, 24, 9, 41, 34, 0)
+	expect.Any(l.In(time.UTC)).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+	expect.Any(l.In(newYork)).ToBe(t, Date(2017, 4, 24, 13, 41, 34, 0, time.UTC))
+
+	expect.Any(LocalDateTimeOf(l.In(newYork).Time)).ToBe(t, l)
+	expect.Any(LocalDateTimeOf(l.In(newYork).UTC().Time)).ToBe(t, NewLocalDateTime(2017, 4, 24, 13, 41, 34, 0))
+}
+
+func TestLocalDateTime_accessors(t *testing.T) {
+	l := NewLocalDateTime(2017, 4, 24, 9, 41, 34, 502)
+
+	y, m, d := l.Date()
+	expect.Number(y).ToBe(t, 2017)
+	expect.Number(m).ToBe(t, time.April)
+	expect.Number(d).ToBe(t, 24)
+
+	hh, mm, ss := l.Clock()
+	expect.Number(hh).ToBe(t, 9)
+	expect.Number(mm).ToBe(t, 41)
+	expect.Number(ss).ToBe(t, 34)
+	expect.Number(l.Nanosecond()).ToBe(t, 502)
+
+	later := NewLocalDateTime(2017, 4, 24, 9, 41, 35, 0)
+	expect.Bool(l.Before(later)).ToBeTrue(t)
+	expect.Bool(l.After(later)).ToBeFalse(t)
+	expect.Number(l.Compare(later)).ToBe(t, -1)
+	expect.Bool(l.IsZero()).ToBeFalse(t)
+	expect.Bool(LocalDateTime{}.IsZero()).ToBeTrue(t)
+}
+
+func TestLocalDateTime_Marshaling(t *testing.T) {
+	l := NewLocalDateTime(2017, 4, 24, 9, 41, 34, 502000000)
+	expect.String(l.String()).ToBe(t, "2017-04-24T09:41:34.502")
+
+	t.Run("text", func(t *testing.T) {
+		b, err := l.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T09:41:34.502")
+
+		var l2 LocalDateTime
+		err = l2.UnmarshalText(b)
+		expect.Any(l2, err).ToBe(t, l)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(l)
+		expect.String(b, err).ToEqual(t, `"2017-04-24T09:41:34.502"`)
+
+		var l2 LocalDateTime
+		err = json.Unmarshal(b, &l2)
+		expect.Any(l2, err).ToBe(t, l)
+
+		expect.Any(l2.UnmarshalJSON([]byte(`2017`))).ToBe(t, ErrNotString)
+		expect.Error(l2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+
+	t.Run("format", func(t *testing.T) {
+		defer func() { MarshalTextFormat = RFC3339Nano }()
+		MarshalTextFormat = RFC3339
+		b, err := l.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T09:41:34")
+
+		// any numeric zone is omitted
+		for _, zone := range []string{"Z07:00", "-07:00", "Z0700", "-0700", "Z07:00:00", "-070000", "Z07"} {
+			MarshalTextFormat = "2006-01-02T15:04:05.000" + zone
+			b, err = l.MarshalText()
+			expect.String(b, err).ToEqual(t, "2017-04-24T09:41:34.502")
+		}
+	})
+
+	t.Run("year out of range", func(t *testing.T) {
+		_, err := NewLocalDateTime(10000, 1, 1, 0, 0, 0, 0).MarshalText()
+		expect.Error(err).ToContain(t, "LocalDateTime.MarshalText: year 10000 is outside the range 0-9999")
+		_, err = NewLocalDateTime(-1, 12, 31, 23, 59, 0, 0).MarshalJSON()
+		expect.Error(err).ToContain(t, "LocalDateTime.MarshalJSON: year -1 is outside the range 0-9999")
+	})
+}
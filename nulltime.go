@@ -0,0 +1,45 @@
+package iso8601
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// NullTime represents a Time that may be null. It implements the sql.Scanner and
+// driver.Valuer interfaces, in the same way as sql.NullTime, so it can be used for
+// nullable columns:
+//
+//	var modified iso8601.NullTime
+//	err := row.Scan(&modified)
+//	if modified.Valid {
+//		// use modified.Time
+//	}
+type NullTime struct {
+	Time  Time
+	Valid bool // Valid is true if Time is not NULL
+}
+
+var (
+	_ sql.Scanner   = &NullTime{}
+	_ driver.Valuer = NullTime{}
+)
+
+// Scan implements the sql.Scanner interface. It accepts the same values as Time.Scan;
+// nil sets Valid to false.
+func (n *NullTime) Scan(value any) error {
+	if value == nil {
+		n.Time, n.Valid = Time{}, false
+		return nil
+	}
+	err := n.Time.Scan(value)
+	n.Valid = err == nil
+	return err
+}
+
+// Value implements the driver.Valuer interface. It is nil unless Valid is true.
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time.Value()
+}
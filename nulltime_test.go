@@ -0,0 +1,32 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestNullTime_sql(t *testing.T) {
+	tm := Date(2017, 4, 24, 9, 41, 34, 0, time.UTC)
+
+	v, err := NullTime{Time: tm, Valid: true}.Value()
+	expect.Any(v, err).ToBe(t, time.Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+
+	v, err = NullTime{Time: tm}.Value()
+	expect.Any(v, err).ToBe(t, nil)
+
+	var n NullTime
+	err = n.Scan("2017-04-24 09:41:34Z")
+	expect.Any(n, err).ToBe(t, NullTime{Time: tm, Valid: true})
+
+	err = n.Scan(nil)
+	expect.Any(n, err).ToBe(t, NullTime{})
+
+	err = n.Scan(time.Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+	expect.Any(n, err).ToBe(t, NullTime{Time: tm, Valid: true})
+
+	err = n.Scan(42)
+	expect.Error(err).ToContain(t, "Time.Scan: unsupported type int")
+	expect.Bool(n.Valid).ToBeFalse(t)
+}
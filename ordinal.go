@@ -0,0 +1,33 @@
+package iso8601
+
+import "time"
+
+// FormatOrdinal renders the date part of t as an ISO-8601 ordinal date in extended format,
+// YYYY-DDD, where DDD is the day of the year from 001 to 365 (366 in leap years).
+// Years outside the range 0000 to 9999 are written as expanded years, e.g. -000044-075.
+// The time of day can be appended using Format, e.g. t.Format("T15:04:05Z07:00").
+func (t Time) FormatOrdinal() string {
+	return string(t.AppendOrdinal(make([]byte, 0, 8)))
+}
+
+// AppendOrdinal is like FormatOrdinal but appends the textual representation to b
+// and returns the extended buffer.
+func (t Time) AppendOrdinal(b []byte) []byte {
+	b = Formatter{}.appendYear(b, t.Year(), 4)
+	b = append(b, '-')
+	return appendInt(b, t.YearDay(), 3)
+}
+
+// daysInYear returns the number of days in a year, which is 365 or 366.
+func daysInYear(year int) int {
+	if isLeap(year) {
+		return 366
+	}
+	return 365
+}
+
+// fromOrdinalDate converts an ordinal date to the corresponding calendar date.
+func fromOrdinalDate(year, yday int) (int, int, int) {
+	y, m, d := time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC).Date()
+	return y, int(m), d
+}
@@ -0,0 +1,34 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestTime_FormatOrdinal(t *testing.T) {
+	expect.String(Date(2017, 4, 24, 9, 41, 0, 0, time.UTC).FormatOrdinal()).ToBe(t, "2017-114")
+	expect.String(Date(2017, 1, 1, 0, 0, 0, 0, time.UTC).FormatOrdinal()).ToBe(t, "2017-001")
+	expect.String(Date(2016, 12, 31, 0, 0, 0, 0, time.UTC).FormatOrdinal()).ToBe(t, "2016-366")
+
+	b := Date(2017, 4, 24, 9, 41, 0, 0, time.UTC).AppendOrdinal([]byte("x"))
+	expect.String(b).ToEqual(t, "x2017-114")
+}
+
+func TestTime_FormatOrdinal_expandedYears(t *testing.T) {
+	cases := map[string]Time{
+		"-000044-075": Date(-44, 3, 15, 0, 0, 0, 0, time.UTC),
+		"-000001-365": Date(-1, 12, 31, 0, 0, 0, 0, time.UTC),
+		"+012017-114": Date(12017, 4, 24, 0, 0, 0, 0, time.UTC),
+	}
+
+	for expected, tm := range cases {
+		s := tm.FormatOrdinal()
+		expect.String(s).ToBe(t, expected)
+
+		parsed, err := ParseString(s)
+		expect.Error(err).ToBeNil(t)
+		expect.Any(parsed.Time).ToBe(t, tm.Time)
+	}
+}
@@ -0,0 +1,372 @@
+package iso8601
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// Form is a set of the notations and kinds of date that ISO-8601 allows. A Parser can be
+// restricted to some of them; see Parser.WithForms.
+type Form uint8
+
+const (
+	// FormBasic is the basic notation, e.g. 20170424T094134Z.
+	FormBasic Form = 1 << iota
+
+	// FormExtended is the extended notation, e.g. 2017-04-24T09:41:34Z.
+	FormExtended
+
+	// FormCalendar is a calendar date, e.g. 2017-04-24.
+	FormCalendar
+
+	// FormWeek is a week date, e.g. 2017-W17-1.
+	FormWeek
+
+	// FormOrdinal is an ordinal date, e.g. 2017-114.
+	FormOrdinal
+
+	// AllForms is every form.
+	AllForms = FormBasic | FormExtended | FormCalendar | FormWeek | FormOrdinal
+)
+
+// Parser parses date-times, accepting only the representations allowed by its Profile
+// and its other settings. The zero value accepts everything that Parse accepts, and has
+// the same settings.
+// Each setting is altered by a With method, which returns a modified copy, e.g.
+//
+//	p := iso8601.NewParser(iso8601.ProfileRFC3339).WithLocation(loc).WithMaxDecimals(3)
+//
+// A Parser is immutable and is safe for concurrent use.
+type Parser struct {
+	profile      Profile
+	loc          *time.Location // nil means UTC
+	excluded     Form           // the forms that are not allowed
+	decimals     int            // the maximum number of decimal places; zero means maxDigits
+	yearDigits   int            // the extra digits in an expanded year; zero means 2
+	zoneMismatch ZoneMismatchPolicy
+	leapSeconds  bool
+	endOfDay     bool
+	trimSpace    bool
+	lenient      bool
+	space        bool
+	retainText   bool
+}
+
+// NewParser returns a Parser that accepts the representations allowed by the profile.
+func NewParser(profile Profile) Parser {
+	return Parser{profile: profile}
+}
+
+// defaultParser returns the Parser used by Parse and the other package-level functions.
+func defaultParser() Parser {
+	return Parser{}
+}
+
+// retainingParser returns the Parser used to unmarshal a PreciseTime, which keeps its style.
+func retainingParser() Parser {
+	return Parser{retainText: true}
+}
+
+// expandedYearDigits returns the number of extra digits in an expanded year.
+func (p Parser) expandedYearDigits() int {
+	if p.yearDigits == 0 {
+		return 2
+	}
+	return p.yearDigits
+}
+
+// newFields returns the fields into which an input is scanned, with the parser's settings.
+func (p Parser) newFields() fields {
+	return fields{yearDigits: p.expandedYearDigits(), decimals: p.decimals, truncate: p.lenient, mixed: p.lenient,
+		negZero: profiles[p.profile].negZero}
+}
+
+// trim removes leading and trailing white space from the input, if the parser ignores it.
+func (p Parser) trim(inp []byte) []byte {
+	if p.trimSpace {
+		return bytes.TrimSpace(inp)
+	}
+	return inp
+}
+
+// Profile returns the profile of the parser.
+func (p Parser) Profile() Profile {
+	return p.profile
+}
+
+// Location returns the location used for inputs without a zone designator.
+func (p Parser) Location() *time.Location {
+	if p.loc == nil {
+		return time.UTC
+	}
+	return p.loc
+}
+
+// WithLocation returns a copy of p that interprets inputs without a zone designator as being
+// in the location loc, like ParseInLocation. The default is UTC.
+func (p Parser) WithLocation(loc *time.Location) Parser {
+	p.loc = loc
+	return p
+}
+
+// WithForms returns a copy of p that accepts only the given forms, as well as being limited
+// by its profile. For example, WithForms(FormExtended|FormCalendar) rejects the basic notation,
+// week dates and ordinal dates. Years and centuries on their own are allowed in either notation.
+func (p Parser) WithForms(forms Form) Parser {
+	p.excluded = AllForms &^ forms
+	return p
+}
+
+// WithMaxDecimals returns a copy of p that accepts at most n decimal places in a fraction,
+// which must be between 1 and 9. The default is 9, i.e. nanoseconds.
+func (p Parser) WithMaxDecimals(n int) Parser {
+	p.decimals = min(max(n, 1), maxDigits)
+	return p
+}
+
+// WithExpandedYearDigits returns a copy of p that expects n extra digits in an expanded year,
+// which must be between 1 and 5. ISO-8601 requires the number of extra digits to be agreed
+// by the parties exchanging data. The default, 2, matches the six-digit years used by
+// XML Schema and ECMAScript, e.g. +002017-04-24 or -000044-03-15.
+func (p Parser) WithExpandedYearDigits(n int) Parser {
+	p.yearDigits = min(max(n, 1), 5)
+	return p
+}
+
+// WithZoneMismatch returns a copy of p that applies the policy when an RFC 9557 time zone
+// suffix does not agree with the zone offset. The default is RejectZoneMismatch. The policy
+// does not apply when the suffix is critical (e.g. [!Europe/London]): a mismatch is then
+// always rejected, as RFC 9557 requires. Nor does it apply when the offset is Z, which
+// RFC 9557 treats as giving the instant without a local offset.
+func (p Parser) WithZoneMismatch(policy ZoneMismatchPolicy) Parser {
+	p.zoneMismatch = policy
+	return p
+}
+
+// WithLeapSeconds returns a copy of p that accepts second 60, provided that it is a real
+// leap second, i.e. 23:59:60 UTC at the end of a day in the leap second table. Otherwise,
+// second 60 is rejected with an *iso8601.RangeError, as by the standard library.
+//
+// Because time.Time cannot represent a leap second, the parsed value is normalized to the
+// start of the next minute, as by time.Date, so 2016-12-31T23:59:60.5Z gives
+// 2017-01-01T00:00:00.5Z. Such a PreciseTime reports true from IsLeapSecond.
+func (p Parser) WithLeapSeconds() Parser {
+	p.leapSeconds = true
+	return p
+}
+
+// WithMarshalEndOfDay returns a copy of p whose PreciseTimes, if parsed from 24:00 (the end
+// of a day), are rendered by MarshalText and MarshalJSON in the same form, e.g.
+// 2017-04-24T24:00:00Z, so that they round-trip. Otherwise, they are rendered as midnight at
+// the start of the next day, e.g. 2017-04-25T00:00:00Z, which is the same instant. See also
+// PreciseTime.IsEndOfDay.
+func (p Parser) WithMarshalEndOfDay() Parser {
+	p.endOfDay = true
+	return p
+}
+
+// WithTrimSpace returns a copy of p that ignores leading and trailing white space.
+func (p Parser) WithTrimSpace() Parser {
+	p.trimSpace = true
+	return p
+}
+
+// WithLenient returns a copy of p that accepts some common deviations, even if its profile
+// does not allow them: a lowercase t and z, a space between the date and time, a basic date
+// with an extended time (e.g. 2017114T09:41Z) or vice versa, and more decimal places than
+// allowed (see WithMaxDecimals), which are truncated.
+func (p Parser) WithLenient() Parser {
+	p.lenient = true
+	return p
+}
+
+// WithRetainText returns a copy of p that records the style in which each PreciseTime is
+// written: the notation, the kind of date, the precision, the decimal places and sign, the
+// zone designator and any time zone suffix. MarshalText and MarshalJSON then render the
+// PreciseTime in the same style, even if its time is altered, e.g. 2017-04-24T09:41+01 is
+// marshaled as 2017-04-24T09:41+01 rather than 2017-04-24T09:41:00+01:00. See also
+// PreciseTime.Style. A PreciseTime that is unmarshaled always keeps its style.
+//
+// The style is not the text itself, so the output differs from the input in some cases:
+// components are written with their full number of digits (e.g. 2017-4-24 is rendered as
+// 2017-04-24), tags are dropped, and a time zone suffix is kept only if it is an IANA time
+// zone name, not an offset such as [+01:00]. Leading and trailing white space and the
+// deviations allowed by WithLenient are not kept either.
+func (p Parser) WithRetainText() Parser {
+	p.retainText = true
+	return p
+}
+
+// WithSpaceSeparator returns a copy of p that accepts a space instead of T between the date
+// and the time, as RFC 3339 allows by agreement between the parties exchanging data. It has
+// no effect on the other profiles; see WithLenient.
+func (p Parser) WithSpaceSeparator() Parser {
+	p.space = true
+	return p
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Parse parses a date-time according to the parser's settings. If the input is not allowed
+// by the profile, the *iso8601.SyntaxError names the profile and explains which rule was broken.
+// Otherwise, Parse behaves like the Parse function.
+//
+// Other than ProfileISO8601, the profiles do not allow an RFC 9557 suffix.
+func (p Parser) Parse(inp []byte) (Time, error) {
+	t, err := p.ParseWithPrecision(inp)
+	return t.Time, err
+}
+
+// ParseString parses a date-time string according to the parser's settings; see Parse.
+func (p Parser) ParseString(inp string) (Time, error) {
+	return p.Parse([]byte(inp))
+}
+
+// ParseWithPrecision is like Parse but returns a PreciseTime, which carries the precision of
+// the input; see the ParseWithPrecision function.
+func (p Parser) ParseWithPrecision(inp []byte) (PreciseTime, error) {
+	t, tags, err := p.parse(inp)
+	if err != nil {
+		return PreciseTime{}, err
+	}
+
+	for _, tag := range tags {
+		if tag.Critical && !tag.isSupported() {
+			return PreciseTime{}, &SyntaxError{Value: string(inp), Element: "suffix", Reason: "critical tag " + tag.String() + " is not supported"}
+		}
+	}
+
+	return t, nil
+}
+
+// parse parses the input and returns the tags of its RFC 9557 suffix, if it has one.
+func (p Parser) parse(inp []byte) (PreciseTime, []Tag, error) {
+	b := p.trim(inp)
+
+	// the RFC 9557 suffix, if any, starts at b[k]
+	k := bytes.IndexByte(b, '[')
+
+	norm, err := p.normalize(b, k)
+	if err != nil {
+		return PreciseTime{}, nil, withValue(err, b, inp)
+	}
+
+	t, tags, err := p.parseWithSuffix(norm, k)
+	if err != nil {
+		return PreciseTime{}, nil, withValue(err, norm, inp)
+	}
+
+	return t, tags, nil
+}
+
+// normalize checks the characters that are allowed in place of T and Z, and returns the input
+// with them replaced by T and Z, so that it can be scanned. The suffix, if any, starts at inp[k].
+// The input is only copied if necessary.
+func (p Parser) normalize(inp []byte, k int) ([]byte, error) {
+	if p.profile == ProfileISO8601 && !p.lenient {
+		// nothing is allowed in place of T and Z
+		return inp, nil
+	}
+
+	r := &profiles[p.profile]
+	strict := p.profile != ProfileISO8601
+	fail := func(rn rune, reason string) error {
+		return &SyntaxError{Value: string(inp), Element: "date-time", Rune: rn, Reason: r.name + " " + reason}
+	}
+
+	body := inp
+	if k >= 0 {
+		if strict {
+			return nil, &SyntaxError{Value: string(inp), Element: "suffix", Reason: r.name + " does not allow a suffix"}
+		}
+		body = inp[:k]
+	}
+
+	sep := bytes.IndexAny(body, "Tt ")
+	var last byte
+	if len(body) > 0 {
+		last = body[len(body)-1]
+	}
+
+	lowercase := r.lowercase || p.lenient
+	space := r.space || p.lenient || (p.space && p.profile == ProfileRFC3339)
+
+	switch {
+	case !strict:
+	case sep >= 0 && body[sep] == 't' && !lowercase:
+		return nil, fail('t', "requires an upper-case T")
+	case sep >= 0 && body[sep] == ' ' && !space:
+		return nil, fail(' ', "does not allow a space between the date and time")
+	case last == 'z' && !lowercase:
+		return nil, fail('z', "requires an upper-case Z")
+	}
+
+	replaceSep := sep >= 0 && (body[sep] == 't' && lowercase || body[sep] == ' ' && space)
+	replaceZ := last == 'z' && lowercase
+	if !replaceSep && !replaceZ {
+		return inp, nil
+	}
+
+	norm := bytes.Clone(inp)
+	if replaceSep {
+		norm[sep] = 'T'
+	}
+	if replaceZ {
+		norm[len(body)-1] = 'Z'
+	}
+	return norm, nil
+}
+
+// check returns an *iso8601.SyntaxError if the scanned fields f are not allowed by the
+// parser's forms or its profile. It is called before the fields are validated.
+func (p Parser) check(inp []byte, f *fields) error {
+	if p.excluded == 0 && p.profile == ProfileISO8601 {
+		return nil
+	}
+
+	if err := p.checkForms(inp, f); err != nil {
+		return err
+	}
+	return p.profile.check(inp, f)
+}
+
+// checkForms returns an *iso8601.SyntaxError if the date in the scanned fields f is not
+// allowed by the parser's forms.
+func (p Parser) checkForms(inp []byte, f *fields) error {
+	if p.excluded == 0 {
+		return nil
+	}
+
+	fail := func(form string) error {
+		return &SyntaxError{Value: string(inp), Element: "date", Reason: form + " is not allowed"}
+	}
+
+	switch {
+	case f.basic && p.excluded&FormBasic != 0:
+		return fail("the basic format")
+	case !f.basic && f.p > PrecisionYear && p.excluded&FormExtended != 0:
+		return fail("the extended format")
+	case f.form == calendarDate && f.p > PrecisionYear && p.excluded&FormCalendar != 0:
+		return fail("a calendar date")
+	case f.form == weekDate && p.excluded&FormWeek != 0:
+		return fail("a week date")
+	case f.form == ordinalDate && p.excluded&FormOrdinal != 0:
+		return fail("an ordinal date")
+	}
+	return nil
+}
+
+// withValue alters a parse error that reports the parsed input so that it reports the original
+// input instead, which may differ if it was trimmed or normalized.
+func withValue(err error, parsed, inp []byte) error {
+	var se *SyntaxError
+	var re *RangeError
+	switch {
+	case errors.As(err, &se) && se.Value == string(parsed):
+		se.Value = string(inp)
+	case errors.As(err, &re) && re.Value == string(parsed):
+		re.Value = string(inp)
+	}
+	return err
+}
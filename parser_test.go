@@ -0,0 +1,205 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParser_zero(t *testing.T) {
+	var p Parser
+	expect.Any(p.Profile()).ToBe(t, ProfileISO8601)
+
+	for _, inp := range []string{"2017-04-24", "2017-04-24T09", "20170424T0941", "2017-W17-1", "2017-04-24T09:41:34Z[Europe/London]"} {
+		expected, err := ParseString(inp)
+		expect.Error(err).ToBeNil(t)
+		actual, err := p.ParseString(inp)
+		expect.Any(actual, err).ToBe(t, expected)
+	}
+}
+
+func TestParser_WithSpaceSeparator(t *testing.T) {
+	p := NewParser(ProfileRFC3339)
+	_, err := p.ParseString("2017-04-24 09:41:34Z")
+	expect.Error(err).ToContain(t, "does not allow a space")
+
+	tm, err := p.WithSpaceSeparator().ParseString("2017-04-24 09:41:34Z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+
+	// the original parser is unchanged
+	_, err = p.ParseString("2017-04-24 09:41:34Z")
+	expect.Error(err).ToContain(t, "does not allow a space")
+
+	_, err = NewParser(ProfileW3CDTF).WithSpaceSeparator().ParseString("2017-04-24 09:41Z")
+	expect.Error(err).ToContain(t, "W3CDTF does not allow a space")
+}
+
+func TestParser_precision(t *testing.T) {
+	p := NewParser(ProfileW3CDTF)
+
+	tm, err := p.ParseWithPrecision([]byte("2017-04"))
+	expect.Error(err).ToBeNil(t)
+	expect.Any(tm.Precision()).ToBe(t, PrecisionMonth)
+
+	tm, err = p.ParseWithPrecision([]byte("2017-04-24T09:41Z"))
+	expect.Error(err).ToBeNil(t)
+	expect.Any(tm.Precision()).ToBe(t, PrecisionMinute)
+}
+
+func TestParser_errorValue(t *testing.T) {
+	// the error reports the input, not the normalized form that was parsed
+	_, err := NewParser(ProfileRFC3339).ParseString("2017-04-31t09:41:34z")
+	expect.Error(err).ToContain(t, `Cannot parse "2017-04-31t09:41:34z": day 31 is not in range 1-30`)
+
+	_, err = NewParser(ProfileHTML5).ParseString("2017-04-24 09:61Z")
+	expect.Error(err).ToContain(t, `Cannot parse "2017-04-24 09:61Z": minute 61 is not in range 0-59`)
+}
+
+func TestParser_WithLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	p := NewParser(ProfileISO8601).WithLocation(tokyo)
+	expect.Any(p.Location()).ToBe(t, tokyo)
+	expect.Any(Parser{}.Location()).ToBe(t, time.UTC)
+
+	tm, err := p.ParseString("2017-04-24T09:41:34")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 0, tokyo))
+
+	tm, err = p.ParseString("2017-04-24T09:41:34Z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+}
+
+func TestParser_WithForms(t *testing.T) {
+	p := Parser{}.WithForms(FormExtended | FormCalendar)
+
+	cases := map[string]string{
+		"2017-04-24T09:41Z": "",
+		"2017":              "",
+		"2017-04":           "",
+		"20170424T0941Z":    "the basic format is not allowed",
+		"2017-W17-1":        "a week date is not allowed",
+		"2017-114":          "an ordinal date is not allowed",
+	}
+
+	for inp, msg := range cases {
+		t.Run(inp, func(t *testing.T) {
+			_, err := p.ParseString(inp)
+			if msg == "" {
+				expect.Error(err).ToBeNil(t)
+			} else {
+				expect.Error(err).ToContain(t, msg)
+			}
+		})
+	}
+
+	_, err := Parser{}.WithForms(FormBasic | FormWeek).ParseString("2017-W17-1")
+	expect.Error(err).ToContain(t, "the extended format is not allowed")
+	_, err = Parser{}.WithForms(FormBasic | FormWeek).ParseString("20170424")
+	expect.Error(err).ToContain(t, "a calendar date is not allowed")
+	_, err = Parser{}.WithForms(FormBasic | FormWeek).ParseString("2017W171")
+	expect.Error(err).ToBeNil(t)
+}
+
+func TestParser_WithMaxDecimals(t *testing.T) {
+	p := Parser{}.WithMaxDecimals(3)
+
+	tm, err := p.ParseString("2017-04-24T09:41:34.502Z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 502_000_000, time.UTC))
+
+	_, err = p.ParseString("2017-04-24T09:41:34.5021Z")
+	expect.Error(err).ToContain(t, `invalid fraction; at most 3 decimal places are allowed`)
+
+	tm, err = p.WithLenient().ParseString("2017-04-24T09:41:34.5029Z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 502_000_000, time.UTC))
+
+	tm, err = Parser{}.WithLenient().ParseString("2017-04-24T09:41:34.1234567891234Z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 123_456_789, time.UTC))
+
+	_, err = Parser{}.ParseString("2017-04-24T09:41:34.1234567891234Z")
+	expect.Any(err).ToBe(t, ErrPrecision)
+}
+
+func TestParser_WithTrimSpace(t *testing.T) {
+	_, err := Parser{}.ParseString(" 2017-04-24T09:41Z\n")
+	expect.Error(err).ToHaveOccurred(t)
+
+	tm, err := Parser{}.WithTrimSpace().ParseString(" 2017-04-24T09:41Z\n")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 0, 0, time.UTC))
+
+	_, err = Parser{}.WithTrimSpace().ParseString(" 2017-04-31T09:41Z\n")
+	expect.Error(err).ToContain(t, `Cannot parse " 2017-04-31T09:41Z\n": day 31`)
+}
+
+func TestParser_WithLenient(t *testing.T) {
+	_, err := Parser{}.ParseString("2017-04-24t09:41z")
+	expect.Error(err).ToHaveOccurred(t)
+
+	p := Parser{}.WithLenient()
+	for _, inp := range []string{"2017-04-24t09:41z", "2017-04-24 09:41Z", "2017-04-24t09:41z[u-ca=gregory]",
+		"2017114T09:41Z", "20170424T09:41Z", "2017-04-24T0941Z"} {
+		tm, err := p.ParseString(inp)
+		expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 0, 0, time.UTC))
+	}
+
+	tm, err := NewParser(ProfileXMLSchemaDateTime).WithLenient().ParseString("2017-04-24 09:41:34z")
+	expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 0, time.UTC))
+}
+
+func TestParser_otherTypes(t *testing.T) {
+	p := Parser{}.WithTrimSpace().WithExpandedYearDigits(3).WithMaxDecimals(3).WithForms(FormExtended | FormCalendar | FormWeek)
+
+	d, err := p.ParseLocalDate([]byte(" +0002017-04-24\n"))
+	expect.Any(d, err).ToBe(t, NewLocalDate(2017, 4, 24))
+	_, err = p.ParseLocalDate([]byte("2017-114"))
+	expect.Error(err).ToContain(t, "an ordinal date is not allowed")
+	_, err = p.ParseLocalDate([]byte(" 20170424"))
+	expect.Error(err).ToContain(t, `Cannot parse " 20170424": invalid date; the basic format is not allowed`)
+
+	l, err := p.ParseLocalDateTime([]byte(" 2017-04-24T09:41:34.502\n"))
+	expect.Any(l, err).ToBe(t, NewLocalDateTime(2017, 4, 24, 9, 41, 34, 502_000_000))
+	_, err = p.ParseLocalDateTime([]byte("2017-04-24T09:41:34.5021"))
+	expect.Error(err).ToContain(t, "at most 3 decimal places are allowed")
+	_, err = NewParser(ProfileRFC3339).ParseLocalDateTime([]byte("2017-04-24T09:41:34"))
+	expect.Error(err).ToContain(t, "RFC 3339")
+	l, err = NewParser(ProfileXMLSchemaDateTime).WithLenient().ParseLocalDateTime([]byte("2017-04-24 09:41:34"))
+	expect.Any(l, err).ToBe(t, NewLocalDateTime(2017, 4, 24, 9, 41, 34, 0))
+
+	c, err := p.ParseClock([]byte(" 09:41:34.502\n"))
+	expect.Any(c, err).ToBe(t, NewClock(9, 41, 34, 502_000_000))
+	_, err = p.ParseClock([]byte("09:41:34.5021"))
+	expect.Error(err).ToContain(t, "at most 3 decimal places are allowed")
+	c, err = p.WithLenient().ParseClock([]byte("09:41:34.5029"))
+	expect.Any(c, err).ToBe(t, NewClock(9, 41, 34, 502_000_000))
+
+	w, err := p.ParseWeek([]byte(" +0002017-W17\n"))
+	expect.Any(w, err).ToBe(t, Week{Year: 2017, Week: 17})
+	_, err = p.WithForms(FormExtended).ParseWeek([]byte("2017-W17"))
+	expect.Error(err).ToContain(t, "a week date is not allowed")
+
+	pd, err := p.ParsePeriod([]byte(" PT0.5S\n"))
+	expect.Any(pd.String(), err).ToBe(t, "PT0.5S")
+	_, err = p.ParsePeriod([]byte("PT0.5021S"))
+	expect.Error(err).ToContain(t, "at most 3 decimal places are allowed")
+	_, err = p.ParsePeriod([]byte("P0001-02-10T02:30:00.5021"))
+	expect.Error(err).ToContain(t, "at most 3 decimal places are allowed")
+	pd, err = p.WithLenient().ParsePeriod([]byte("P0001-02-10T02:30:00.5029"))
+	expect.Any(pd.String(), err).ToBe(t, "P1Y2M10DT2H30M0.502S")
+}
+
+func TestParser_concurrent(t *testing.T) {
+	p := NewParser(ProfileRFC3339).WithSpaceSeparator().WithMaxDecimals(6)
+
+	done := make(chan struct{})
+	for range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for range 100 {
+				tm, err := p.ParseString("2017-04-24 09:41:34.502z")
+				expect.Any(tm, err).ToBe(t, Date(2017, 4, 24, 9, 41, 34, 502_000_000, time.UTC))
+			}
+		}()
+	}
+	for range 8 {
+		<-done
+	}
+}
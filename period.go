@@ -0,0 +1,623 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Period holds an ISO-8601 duration, such as P1Y2M10DT2H30M, PT0.5S or P3W.
+//
+// The years, months, weeks and days are nominal components, whose lengths depend on the
+// calendar; they are kept separate from the hours, minutes and seconds, which are exact.
+// Each component can hold a decimal fraction with up to nine decimal places, although when
+// parsing, only the lowest-order component present may have one.
+//
+// A negative period normally has all its components negative; it is written with a leading
+// minus sign, e.g. -P1Y2M. Components with mixed signs are written with their own signs.
+//
+// The zero value is a zero period, written as PT0S.
+type Period struct {
+	// each component is a fixed-point number in units of 10^-9
+	years, months, weeks, days int64
+	hours, minutes, seconds    int64
+}
+
+// fixedOne is the unit of the fixed-point components of a Period.
+const fixedOne = 1_000_000_000
+
+var _ json.Unmarshaler = &Period{}
+
+// NewPeriod returns a Period with the given components. The components should all have
+// the same sign; a negative period has all its components negative or zero.
+func NewPeriod(years, months, weeks, days, hours, minutes, seconds int) Period {
+	return Period{
+		years:   int64(years) * fixedOne,
+		months:  int64(months) * fixedOne,
+		weeks:   int64(weeks) * fixedOne,
+		days:    int64(days) * fixedOne,
+		hours:   int64(hours) * fixedOne,
+		minutes: int64(minutes) * fixedOne,
+		seconds: int64(seconds) * fixedOne,
+	}
+}
+
+// ParsePeriod parses an ISO-8601 duration. Two forms are accepted:
+//
+//   - the designator form, PnYnMnWnDTnHnMnS, in which any of the components can be omitted
+//     (but at least one must be present) and the lowest-order component present may have a
+//     decimal fraction, e.g. P1Y2M10DT2H30M, P3W or PT0.5S;
+//   - the alternative form, PYYYY-MM-DDThh:mm:ss (extended) or PYYYYMMDDThhmmss (basic),
+//     in which the time part is optional and the seconds may have a decimal fraction,
+//     e.g. P0001-02-10T02:30:00.
+//
+// A leading sign is allowed, e.g. -P1D. In the designator form, individual components can
+// also have a minus sign, e.g. P1Y-2M. The decimal sign can be a full stop or a comma.
+func ParsePeriod(inp []byte) (Period, error) {
+	return defaultParser().ParsePeriod(inp)
+}
+
+// ParsePeriodString parses an ISO-8601 duration string; see ParsePeriod.
+func ParsePeriodString(inp string) (Period, error) {
+	return ParsePeriod([]byte(inp))
+}
+
+// ParsePeriod is like the ParsePeriod function but uses the parser's maximum decimal places,
+// leniency and white space setting. The profile and forms do not apply.
+func (p Parser) ParsePeriod(inp []byte) (Period, error) {
+	b := p.trim(inp)
+	d, err := p.parsePeriod(b)
+	if err != nil {
+		return Period{}, withValue(err, b, inp)
+	}
+	return d, nil
+}
+
+// parsePeriod parses the period, once any white space has been trimmed.
+func (p Parser) parsePeriod(inp []byte) (Period, error) {
+	var d Period
+	f := p.newFields()
+
+	i := 0
+	neg := false
+	if len(inp) > 0 && (inp[0] == '+' || inp[0] == '-') {
+		neg = inp[0] == '-'
+		i++
+	}
+
+	if i == len(inp) || inp[i] != 'P' {
+		return Period{}, errPeriodSyntax(inp, i)
+	}
+	i++
+
+	var err error
+	if j := scanDigits(inp, i); j-i >= 4 && (j == len(inp) || inp[j] == '-' || inp[j] == 'T') {
+		err = d.parseAlternative(inp, i, &f)
+	} else {
+		err = d.parseDesignators(inp, i, &f)
+	}
+
+	if err != nil {
+		return Period{}, err
+	}
+
+	if neg {
+		d = d.Negate()
+	}
+	return d, nil
+}
+
+// parseDesignators parses PnYnMnWnDTnHnMnS starting at inp[i], just after the 'P'. The
+// settings in f limit the decimal places.
+func (p *Period) parseDesignators(inp []byte, i int, f *fields) error {
+	// the components, in the order they must appear; the month and minute
+	// designators are the same character so the time part uses its own list
+	dateParts := [...]struct {
+		designator byte
+		field      *int64
+	}{{'Y', &p.years}, {'M', &p.months}, {'W', &p.weeks}, {'D', &p.days}}
+	timeParts := [...]struct {
+		designator byte
+		field      *int64
+	}{{'H', &p.hours}, {'M', &p.minutes}, {'S', &p.seconds}}
+
+	parts := dateParts[:]
+	inTime := false
+	found := false
+	fraction := false
+
+	for i < len(inp) {
+		if inp[i] == 'T' && !inTime {
+			if i+1 == len(inp) {
+				return errPeriodSyntax(inp, i)
+			}
+			inTime = true
+			parts = timeParts[:]
+			i++
+			continue
+		}
+
+		if fraction {
+			// only the lowest-order component can have a fraction
+			return &SyntaxError{Value: string(inp), Element: "period", Rune: rune(inp[i]), Reason: "only the last component may have a fraction"}
+		}
+
+		v, j, hasFraction, err := f.scanDecimal(inp, i)
+		if err != nil {
+			return err
+		}
+		if j == len(inp) {
+			return &SyntaxError{Value: string(inp), Element: "period", Reason: "missing designator"}
+		}
+
+		k := 0
+		for k < len(parts) && parts[k].designator != inp[j] {
+			k++
+		}
+		if k == len(parts) {
+			return errPeriodSyntax(inp, j)
+		}
+
+		*parts[k].field = v
+		parts = parts[k+1:]
+		found = true
+		fraction = hasFraction
+		i = j + 1
+	}
+
+	if !found {
+		return &SyntaxError{Value: string(inp), Element: "period", Reason: "no components"}
+	}
+	return nil
+}
+
+// parseAlternative parses PYYYY-MM-DDThh:mm:ss or PYYYYMMDDThhmmss starting at inp[i],
+// just after the 'P'. This reuses the date-time scanner, with the settings in f, then checks that
+// each component does not exceed its carry-over point, as the standard requires.
+func (p *Period) parseAlternative(inp []byte, i int, f *fields) error {
+	j, err := f.parseDate(inp, i)
+	if err != nil {
+		return err
+	}
+
+	if f.form != calendarDate || f.p != PrecisionDay {
+		return &SyntaxError{Value: string(inp), Element: "period"}
+	}
+
+	if j < len(inp) {
+		if inp[j] != 'T' {
+			return errPeriodSyntax(inp, j)
+		}
+
+		j, err = f.parseTime(inp, j+1)
+		if err != nil {
+			return err
+		}
+
+		if j < len(inp) {
+			return errPeriodSyntax(inp, j)
+		}
+	}
+
+	limits := [...]struct {
+		element    string
+		given, max int
+	}{{"month", f.M, 12}, {"day", f.d, 30}, {"hour", f.h, 24}, {"minute", f.m, 59}, {"second", f.s, 59}}
+
+	for _, l := range limits {
+		if l.given > l.max {
+			return &RangeError{Value: string(inp), Element: l.element, Given: l.given, Min: 0, Max: l.max}
+		}
+	}
+
+	*p = Period{
+		years:   int64(f.Y) * fixedOne,
+		months:  int64(f.M) * fixedOne,
+		days:    int64(f.d) * fixedOne,
+		hours:   int64(f.h) * fixedOne,
+		minutes: int64(f.m) * fixedOne,
+		seconds: int64(f.s)*fixedOne + int64(f.fraction),
+	}
+	return nil
+}
+
+// scanDecimal scans an optionally signed decimal number starting at inp[i], returning its value
+// as a fixed-point number, the index of the first byte after it and whether it had a fraction.
+// The fraction is limited to f.maxDecimals() places.
+func (f *fields) scanDecimal(inp []byte, i int) (int64, int, bool, error) {
+	neg := i < len(inp) && inp[i] == '-'
+	if neg {
+		i++
+	}
+
+	j := scanDigits(inp, i)
+	if j == i {
+		return 0, 0, false, errPeriodSyntax(inp, i)
+	}
+
+	var v int64
+	for _, c := range inp[i:j] {
+		v = v*10 + int64(c) - int64(charStart)
+		if v > math.MaxInt64/fixedOne-1 {
+			return 0, 0, false, &SyntaxError{Value: string(inp), Element: "period", Reason: "too many digits"}
+		}
+	}
+	v *= fixedOne
+	hasFraction := j < len(inp) && (inp[j] == '.' || inp[j] == ',')
+
+	if hasFraction {
+		k := scanDigits(inp, j+1)
+		n := k - (j + 1)
+		if limit := f.maxDecimals(); n > limit {
+			switch {
+			case f.truncate:
+				n = limit
+			case n > maxDigits:
+				return 0, 0, false, ErrPrecision
+			default:
+				return 0, 0, false, &SyntaxError{Value: string(inp), Element: "period", Reason: "at most " + strconv.Itoa(limit) + " decimal places are allowed"}
+			}
+		}
+
+		frac := int64(atoi(inp[j+1 : j+1+n]))
+		for ; n < 9; n++ {
+			frac *= 10
+		}
+		v += frac
+		j = k
+	}
+
+	if neg {
+		v = -v
+	}
+	return v, j, hasFraction, nil
+}
+
+func errPeriodSyntax(inp []byte, i int) error {
+	if i < len(inp) {
+		return &SyntaxError{Value: string(inp), Element: "period", Rune: rune(inp[i])}
+	}
+	return &SyntaxError{Value: string(inp), Element: "period"}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Years returns the whole number of years in the period.
+func (p Period) Years() int {
+	return int(p.years / fixedOne)
+}
+
+// Months returns the whole number of months in the period.
+func (p Period) Months() int {
+	return int(p.months / fixedOne)
+}
+
+// Weeks returns the whole number of weeks in the period.
+func (p Period) Weeks() int {
+	return int(p.weeks / fixedOne)
+}
+
+// Days returns the whole number of days in the period.
+func (p Period) Days() int {
+	return int(p.days / fixedOne)
+}
+
+// Hours returns the whole number of hours in the period.
+func (p Period) Hours() int {
+	return int(p.hours / fixedOne)
+}
+
+// Minutes returns the whole number of minutes in the period.
+func (p Period) Minutes() int {
+	return int(p.minutes / fixedOne)
+}
+
+// Seconds returns the whole number of seconds in the period.
+func (p Period) Seconds() int {
+	return int(p.seconds / fixedOne)
+}
+
+// YearsFloat returns the number of years in the period, including any fraction.
+func (p Period) YearsFloat() float64 {
+	return float64(p.years) / fixedOne
+}
+
+// MonthsFloat returns the number of months in the period, including any fraction.
+func (p Period) MonthsFloat() float64 {
+	return float64(p.months) / fixedOne
+}
+
+// WeeksFloat returns the number of weeks in the period, including any fraction.
+func (p Period) WeeksFloat() float64 {
+	return float64(p.weeks) / fixedOne
+}
+
+// DaysFloat returns the number of days in the period, including any fraction.
+func (p Period) DaysFloat() float64 {
+	return float64(p.days) / fixedOne
+}
+
+// HoursFloat returns the number of hours in the period, including any fraction.
+func (p Period) HoursFloat() float64 {
+	return float64(p.hours) / fixedOne
+}
+
+// MinutesFloat returns the number of minutes in the period, including any fraction.
+func (p Period) MinutesFloat() float64 {
+	return float64(p.minutes) / fixedOne
+}
+
+// SecondsFloat returns the number of seconds in the period, including any fraction.
+func (p Period) SecondsFloat() float64 {
+	return float64(p.seconds) / fixedOne
+}
+
+// IsZero reports whether all the components of p are zero.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// IsNegative reports whether p is negative, i.e. none of its components is positive and
+// at least one is negative.
+func (p Period) IsNegative() bool {
+	anyNeg := false
+	for _, v := range p.components() {
+		if v > 0 {
+			return false
+		}
+		anyNeg = anyNeg || v < 0
+	}
+	return anyNeg
+}
+
+// Negate returns the period with all its components negated.
+func (p Period) Negate() Period {
+	return Period{
+		years:   -p.years,
+		months:  -p.months,
+		weeks:   -p.weeks,
+		days:    -p.days,
+		hours:   -p.hours,
+		minutes: -p.minutes,
+		seconds: -p.seconds,
+	}
+}
+
+// scale returns the period with all its components multiplied by k. It returns false if any
+// of them would overflow, i.e. if k is outside the range ±maxScale.
+func (p Period) scale(k int) (Period, bool) {
+	if k > p.maxScale() || k < -p.maxScale() {
+		return Period{}, false
+	}
+
+	m := int64(k)
+	return Period{
+		years:   p.years * m,
+		months:  p.months * m,
+		weeks:   p.weeks * m,
+		days:    p.days * m,
+		hours:   p.hours * m,
+		minutes: p.minutes * m,
+		seconds: p.seconds * m,
+	}, true
+}
+
+// maxScale returns the largest multiplier for which scale does not overflow.
+func (p Period) maxScale() int {
+	var largest int64
+	for _, v := range p.components() {
+		largest = max(largest, v, -v)
+	}
+	if largest == 0 {
+		return math.MaxInt
+	}
+	return int(min(math.MaxInt64/largest, math.MaxInt))
+}
+
+func (p Period) components() [7]int64 {
+	return [...]int64{p.years, p.months, p.weeks, p.days, p.hours, p.minutes, p.seconds}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the period in the ISO-8601 designator form, e.g. P1Y2M10DT2H30M.
+func (p Period) String() string {
+	return string(p.AppendFormat(make([]byte, 0, 24)))
+}
+
+// AppendFormat is like String but appends the textual representation to b
+// and returns the extended buffer.
+func (p Period) AppendFormat(b []byte) []byte {
+	if p.IsNegative() {
+		b = append(b, '-')
+		p = p.Negate()
+	}
+
+	b = append(b, 'P')
+	b = appendComponent(b, p.years, 'Y')
+	b = appendComponent(b, p.months, 'M')
+	b = appendComponent(b, p.weeks, 'W')
+	b = appendComponent(b, p.days, 'D')
+
+	if p.hours != 0 || p.minutes != 0 || p.seconds != 0 {
+		b = append(b, 'T')
+		b = appendComponent(b, p.hours, 'H')
+		b = appendComponent(b, p.minutes, 'M')
+		b = appendComponent(b, p.seconds, 'S')
+	} else if p.IsZero() {
+		b = append(b, 'T', '0', 'S')
+	}
+
+	return b
+}
+
+// appendComponent appends a non-zero fixed-point component, without trailing zeros in its fraction.
+func appendComponent(b []byte, v int64, designator byte) []byte {
+	if v == 0 {
+		return b
+	}
+
+	if v < 0 {
+		b = append(b, '-')
+		v = -v
+	}
+
+	b = appendInt64(b, v/fixedOne)
+
+	if frac := v % fixedOne; frac != 0 {
+		n := 9
+		for frac%10 == 0 {
+			frac /= 10
+			n--
+		}
+		b = append(b, '.')
+		b = appendInt(b, int(frac), n)
+	}
+
+	return append(b, designator)
+}
+
+func appendInt64(b []byte, v int64) []byte {
+	if v >= fixedOne {
+		b = appendInt64(b, v/fixedOne)
+		return appendInt(b, int(v%fixedOne), 9)
+	}
+	return appendInt(b, int(v), 1)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The period is formatted in the ISO-8601 designator form.
+func (p Period) MarshalText() ([]byte, error) {
+	return p.AppendFormat(make([]byte, 0, 24)), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The period is a quoted string in the ISO-8601 designator form.
+func (p Period) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 26)
+	b = append(b, '"')
+	b = p.AppendFormat(b)
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The period is expected to be in either ISO-8601 form; see ParsePeriod.
+func (p *Period) UnmarshalText(data []byte) (err error) {
+	*p, err = ParsePeriod(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a period.
+func (p *Period) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*p, err = ParsePeriod(b)
+	return err
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// AddPeriod returns the time t+p. Following ISO-8601, the nominal components are applied
+// first: the years, months, weeks and days are added calendrically using AddDate, so the
+// result is normalized in the same way (adding P1M to October 31 yields December 1).
+// The hours, minutes and seconds are then added as an exact duration, so adding PT24H can
+// differ from adding P1D across a daylight saving transition.
+//
+// Fractions of nominal components are handled as follows: a fraction of a year is converted
+// to months and a fraction of a week to days; a fraction of a month is that fraction of the
+// length of the month reached after adding the whole months; a fraction of a day is that
+// fraction of 24 hours, which is added along with the exact components. There is no limit on
+// the length of the period, unlike a time.Duration.
+func (t Time) AddPeriod(p Period) Time {
+	// the whole and fractional parts are separated before they are multiplied, so that
+	// the largest components do not overflow
+	months, monthFrac := carry(p.years/fixedOne*12+p.months/fixedOne, p.years%fixedOne*12+p.months%fixedOne)
+	days, dayFrac := carry(p.weeks/fixedOne*7+p.days/fixedOne, p.weeks%fixedOne*7+p.days%fixedOne)
+
+	r := t.Time
+	if monthFrac != 0 {
+		r = r.AddDate(0, int(months), 0)
+		months = 0
+		days, dayFrac = carry(days, dayFrac+monthFrac*int64(daysIn(r.Month(), r.Year())))
+	}
+
+	r = r.AddDate(0, int(months), int(days))
+
+	// the fixed-point unit is 10^-9 so the fractions are already in nanoseconds
+	secs := p.hours/fixedOne*3600 + p.minutes/fixedOne*60 + p.seconds/fixedOne
+	nanos := dayFrac*86400 + p.hours%fixedOne*3600 + p.minutes%fixedOne*60 + p.seconds%fixedOne
+	return Of(addExact(r, secs, nanos))
+}
+
+// carry moves the whole part of the fixed-point fraction frac into the whole number n.
+func carry(n, frac int64) (int64, int64) {
+	return n + frac/fixedOne, frac % fixedOne
+}
+
+// maxDurationSeconds is the largest number of seconds that a time.Duration can hold.
+const maxDurationSeconds = math.MaxInt64 / int64(time.Second)
+
+// addExact returns t plus secs seconds and nanos nanoseconds. Unlike Add, this is not limited
+// to the range of a time.Duration, about 292 years.
+func addExact(t time.Time, secs, nanos int64) time.Time {
+	if -maxDurationSeconds <= secs && secs <= maxDurationSeconds {
+		return t.Add(time.Duration(secs) * time.Second).Add(time.Duration(nanos))
+	}
+	return time.Unix(t.Unix()+secs, int64(t.Nanosecond())+nanos).In(t.Location())
+}
+
+// SubPeriod returns the time t-p. This is the same as t.AddPeriod(p.Negate()).
+func (t Time) SubPeriod(p Period) Time {
+	return t.AddPeriod(p.Negate())
+}
+
+// PeriodUntil returns the calendar difference between t and u as a period of years, months,
+// days, hours, minutes and seconds, such that t.AddPeriod(t.PeriodUntil(u)) equals u. The
+// calendar arithmetic uses the location of t. If u is before t, the period is negative.
+func (t Time) PeriodUntil(u Time) Period {
+	sign := 1
+	if u.Before(t) {
+		sign = -1
+	}
+
+	// overshoots reports whether x is beyond u, in the direction from t to u
+	overshoots := func(x time.Time) bool {
+		return x.Compare(u.Time)*sign > 0
+	}
+
+	y1, m1, _ := t.Date()
+	y2, m2, _ := u.In(t.Location()).Date()
+
+	months := (y2-y1)*12 + int(m2-m1)
+	for months != 0 && overshoots(t.Time.AddDate(0, months, 0)) {
+		months -= sign
+	}
+
+	// the months and days are added together, as AddPeriod does, so that an intermediate
+	// date that falls in a daylight-saving gap cannot shift the result
+	at := func(days int) time.Time {
+		return t.Time.AddDate(0, months, days)
+	}
+
+	days := int(u.Sub(at(0)) / (24 * time.Hour))
+	for !overshoots(at(days + sign)) {
+		days += sign
+	}
+	for days != 0 && overshoots(at(days)) {
+		days -= sign
+	}
+
+	exact := u.Sub(at(days))
+	return Period{
+		years:   int64(months/12) * fixedOne,
+		months:  int64(months%12) * fixedOne,
+		days:    int64(days) * fixedOne,
+		hours:   int64(exact/time.Hour) * fixedOne,
+		minutes: int64(exact%time.Hour/time.Minute) * fixedOne,
+		seconds: int64(exact % time.Minute),
+	}
+}
@@ -0,0 +1,278 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParsePeriod_ok(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Period
+		str      string
+	}{
+		{input: "P1Y2M10DT2H30M", expected: NewPeriod(1, 2, 0, 10, 2, 30, 0)},
+		{input: "P3W", expected: NewPeriod(0, 0, 3, 0, 0, 0, 0)},
+		{input: "PT36H", expected: NewPeriod(0, 0, 0, 0, 36, 0, 0)},
+		{input: "P1DT12H", expected: NewPeriod(0, 0, 0, 1, 12, 0, 0)},
+		{input: "PT0.5S", expected: Period{seconds: 500_000_000}},
+		{input: "PT0,5S", expected: Period{seconds: 500_000_000}, str: "PT0.5S"},
+		{input: "PT1.000000001S", expected: Period{seconds: 1_000_000_001}},
+		{input: "P0.5Y", expected: Period{years: 500_000_000}},
+		{input: "PT1H1.5M", expected: Period{hours: fixedOne, minutes: 1_500_000_000}},
+		{input: "P1Y2M3W4DT5H6M7S", expected: NewPeriod(1, 2, 3, 4, 5, 6, 7)},
+		{input: "-P1Y2M", expected: NewPeriod(-1, -2, 0, 0, 0, 0, 0)},
+		{input: "+P1D", expected: NewPeriod(0, 0, 0, 1, 0, 0, 0), str: "P1D"},
+		{input: "P1Y-2M", expected: NewPeriod(1, -2, 0, 0, 0, 0, 0)},
+		{input: "PT0S", expected: Period{}},
+		{input: "PT9223372035S", expected: Period{seconds: 9223372035 * fixedOne}},
+		{input: "P0D", expected: Period{}, str: "PT0S"},
+		{input: "P0001-02-10T02:30:00", expected: NewPeriod(1, 2, 0, 10, 2, 30, 0), str: "P1Y2M10DT2H30M"},
+		{input: "P00010210T023000", expected: NewPeriod(1, 2, 0, 10, 2, 30, 0), str: "P1Y2M10DT2H30M"},
+		{input: "P0000-00-00T00:00:01.5", expected: Period{seconds: 1_500_000_000}, str: "PT1.5S"},
+		{input: "P0002-06-00", expected: NewPeriod(2, 6, 0, 0, 0, 0, 0), str: "P2Y6M"},
+		{input: "-P0000-00-01", expected: NewPeriod(0, 0, 0, -1, 0, 0, 0), str: "-P1D"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			p, err := ParsePeriodString(c.input)
+			expect.Any(p, err).ToBe(t, c.expected)
+
+			str := c.str
+			if str == "" {
+				str = c.input
+			}
+			expect.String(p.String()).ToBe(t, str)
+		})
+	}
+}
+
+func TestParsePeriod_error(t *testing.T) {
+	cases := map[string]string{
+		"":                   `Cannot parse "": invalid period`,
+		"1Y":                 `Cannot parse "1Y": invalid period at '1'`,
+		"P":                  `Cannot parse "P": invalid period; no components`,
+		"PT":                 `Cannot parse "PT": invalid period at 'T'`,
+		"P1YT":               `Cannot parse "P1YT": invalid period at 'T'`,
+		"P1":                 `Cannot parse "P1": invalid period; missing designator`,
+		"P1M1Y":              `Cannot parse "P1M1Y": invalid period at 'Y'`,
+		"PT1D":               `Cannot parse "PT1D": invalid period at 'D'`,
+		"P1.5Y2M":            `Cannot parse "P1.5Y2M": invalid period at '2'; only the last component may have a fraction`,
+		"PT1.5H30M":          `Cannot parse "PT1.5H30M": invalid period at '3'; only the last component may have a fraction`,
+		"PxD":                `Cannot parse "PxD": invalid period at 'x'`,
+		"PT9223372036S":      `Cannot parse "PT9223372036S": invalid period; too many digits`,
+		"PT0.1234567891S":    `Too many characters in decimal fraction precision`,
+		"P0001-13-00":        `Cannot parse "P0001-13-00": month 13 is not in range 0-12`,
+		"P0001-00-31":        `Cannot parse "P0001-00-31": day 31 is not in range 0-30`,
+		"P0001-00-00T25:00":  `Cannot parse "P0001-00-00T25:00": hour 25 is not in range 0-24`,
+		"P0001-00-00T02:30Z": `Cannot parse "P0001-00-00T02:30Z": invalid period at 'Z'`,
+		"P0001-02":           `Cannot parse "P0001-02": invalid period`,
+	}
+
+	for input, msg := range cases {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParsePeriodString(input)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestPeriod_accessors(t *testing.T) {
+	p := Period{years: 1_500_000_000, months: 2 * fixedOne, weeks: 3 * fixedOne, days: 4 * fixedOne,
+		hours: 5 * fixedOne, minutes: 6 * fixedOne, seconds: 7_250_000_000}
+
+	expect.Number(p.Years()).ToBe(t, 1)
+	expect.Number(p.Months()).ToBe(t, 2)
+	expect.Number(p.Weeks()).ToBe(t, 3)
+	expect.Number(p.Days()).ToBe(t, 4)
+	expect.Number(p.Hours()).ToBe(t, 5)
+	expect.Number(p.Minutes()).ToBe(t, 6)
+	expect.Number(p.Seconds()).ToBe(t, 7)
+
+	expect.Number(p.YearsFloat()).ToBe(t, 1.5)
+	expect.Number(p.MonthsFloat()).ToBe(t, 2)
+	expect.Number(p.WeeksFloat()).ToBe(t, 3)
+	expect.Number(p.DaysFloat()).ToBe(t, 4)
+	expect.Number(p.HoursFloat()).ToBe(t, 5)
+	expect.Number(p.MinutesFloat()).ToBe(t, 6)
+	expect.Number(p.SecondsFloat()).ToBe(t, 7.25)
+
+	expect.Bool(p.IsZero()).ToBeFalse(t)
+	expect.Bool(Period{}.IsZero()).ToBeTrue(t)
+	expect.Bool(p.IsNegative()).ToBeFalse(t)
+	expect.Bool(p.Negate().IsNegative()).ToBeTrue(t)
+	expect.Bool(Period{}.IsNegative()).ToBeFalse(t)
+	expect.Bool(NewPeriod(1, -2, 0, 0, 0, 0, 0).IsNegative()).ToBeFalse(t)
+}
+
+func TestPeriod_String(t *testing.T) {
+	expect.String(Period{seconds: 3_000_000_000 * fixedOne}.String()).ToBe(t, "PT3000000000S")
+	expect.String(NewPeriod(0, 0, 0, 0, 1, 0, -30).String()).ToBe(t, "PT1H-30S")
+	expect.String(Period{seconds: -1}.String()).ToBe(t, "-PT0.000000001S")
+}
+
+func TestPeriod_Marshaling(t *testing.T) {
+	p := NewPeriod(1, 2, 0, 10, 2, 30, 0)
+
+	t.Run("text", func(t *testing.T) {
+		b, err := p.MarshalText()
+		expect.String(b, err).ToEqual(t, "P1Y2M10DT2H30M")
+
+		var p2 Period
+		err = p2.UnmarshalText(b)
+		expect.Any(p2, err).ToBe(t, p)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		b, err := json.Marshal(p)
+		expect.String(b, err).ToEqual(t, `"P1Y2M10DT2H30M"`)
+
+		var p2 Period
+		err = json.Unmarshal(b, &p2)
+		expect.Any(p2, err).ToBe(t, p)
+
+		expect.Any(p2.UnmarshalJSON([]byte(`P1D`))).ToBe(t, ErrNotString)
+		expect.Error(p2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+}
+
+func TestTime_AddPeriod(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	expect.Error(err).ToBeNil(t)
+
+	t0 := Date(2017, 1, 31, 9, 41, 0, 0, time.UTC)
+
+	cases := []struct {
+		start    Time
+		period   string
+		expected Time
+	}{
+		{t0, "P1Y2M10DT2H30M", Date(2018, 4, 10, 12, 11, 0, 0, time.UTC)},
+		{t0, "P1M", t0.AddDate(0, 1, 0)},
+		{t0, "P1M", Date(2017, 3, 3, 9, 41, 0, 0, time.UTC)},
+		{t0, "P2W", Date(2017, 2, 14, 9, 41, 0, 0, time.UTC)},
+		{t0, "PT0.5S", Date(2017, 1, 31, 9, 41, 0, 500000000, time.UTC)},
+		{t0, "-P1Y1D", Date(2016, 1, 30, 9, 41, 0, 0, time.UTC)},
+		{t0, "P0.5D", Date(2017, 1, 31, 21, 41, 0, 0, time.UTC)},
+		{t0, "P0.5Y", Date(2017, 7, 31, 9, 41, 0, 0, time.UTC)},
+		{t0, "P0.5W", Date(2017, 2, 3, 21, 41, 0, 0, time.UTC)},
+		{Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), "P0.5M", Date(2017, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), "P1.5M", Date(2017, 2, 15, 0, 0, 0, 0, time.UTC)},
+
+		// longer than a time.Duration can hold
+		{t0, "PT3000000H", Date(2359, 4, 29, 9, 41, 0, 0, time.UTC)},
+		{t0, "-PT3000000H30M", Date(1674, 11, 5, 9, 11, 0, 0, time.UTC)},
+		{t0, "PT9000000000.5S", Date(2302, 4, 15, 1, 41, 0, 500000000, time.UTC)},
+
+		// daylight saving started at 2am on 12th March 2017 in New York
+		{Date(2017, 3, 11, 12, 0, 0, 0, ny), "P1D", Date(2017, 3, 12, 12, 0, 0, 0, ny)},
+		{Date(2017, 3, 11, 12, 0, 0, 0, ny), "PT24H", Date(2017, 3, 12, 13, 0, 0, 0, ny)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.start.String()+"+"+c.period, func(t *testing.T) {
+			p, err := ParsePeriodString(c.period)
+			expect.Error(err).ToBeNil(t)
+			expect.Any(c.start.AddPeriod(p)).ToBe(t, c.expected)
+		})
+	}
+}
+
+func TestTime_AddPeriod_limits(t *testing.T) {
+	if math.MaxInt == math.MaxInt32 {
+		t.Skip("years beyond the range of int")
+	}
+
+	t0 := Date(2017, 1, 31, 9, 41, 0, 0, time.UTC)
+
+	// the components are large enough that multiplying them as fixed-point numbers overflows
+	p, err := ParsePeriodString("P800000000Y")
+	expect.Error(err).ToBeNil(t)
+	expect.Any(t0.AddPeriod(p)).ToBe(t, Date(800002017, 1, 31, 9, 41, 0, 0, time.UTC))
+
+	p, err = ParsePeriodString("P2000000000W")
+	expect.Error(err).ToBeNil(t)
+	var days int64 = 14_000_000_000
+	expect.Any(t0.AddPeriod(p)).ToBe(t, t0.AddDate(0, 0, int(days)))
+}
+
+func TestPeriod_scale(t *testing.T) {
+	p := NewPeriod(0, 0, 0, 2, 0, 0, 0)
+
+	s, ok := p.scale(-3)
+	expect.Any(s, ok).ToBe(t, NewPeriod(0, 0, 0, -6, 0, 0, 0))
+
+	_, ok = p.scale(-p.maxScale())
+	expect.Bool(ok).ToBeTrue(t)
+
+	// -math.MinInt overflows, so it must not be compared with maxScale
+	_, ok = p.scale(math.MinInt)
+	expect.Bool(ok).ToBeFalse(t)
+	if p.maxScale() < math.MaxInt {
+		_, ok = p.scale(p.maxScale() + 1)
+		expect.Bool(ok).ToBeFalse(t)
+	}
+}
+
+func TestTime_SubPeriod(t *testing.T) {
+	t0 := Date(2018, 4, 10, 12, 11, 0, 0, time.UTC)
+	p := NewPeriod(1, 2, 0, 10, 2, 30, 0)
+	expect.Any(t0.SubPeriod(p)).ToBe(t, Date(2017, 1, 31, 9, 41, 0, 0, time.UTC))
+	expect.Any(t0.SubPeriod(p)).ToBe(t, t0.AddPeriod(p.Negate()))
+}
+
+func TestTime_PeriodUntil_dst(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	expect.Error(err).ToBeNil(t)
+
+	// times either side of many DST transitions, some of which land in a gap or an overlap
+	rnd := rand.New(rand.NewPCG(1, 2))
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, ny).Unix()
+	at := func() Time {
+		return Of(time.Unix(start+rnd.Int64N(40*365*86400), 0).In(ny))
+	}
+
+	for range 20000 {
+		from, to := at(), at()
+		if actual := from.AddPeriod(from.PeriodUntil(to)); !actual.Equal(to) {
+			t.Fatalf("%s + %s = %s, not %s", from, from.PeriodUntil(to), actual, to)
+		}
+	}
+}
+
+func TestTime_PeriodUntil(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	expect.Error(err).ToBeNil(t)
+
+	cases := []struct {
+		from, to Time
+		expected string
+	}{
+		{Date(2017, 1, 31, 9, 41, 0, 0, time.UTC), Date(2018, 4, 10, 12, 11, 0, 0, time.UTC), "P1Y2M10DT2H30M"},
+		{Date(2017, 1, 31, 0, 0, 0, 0, time.UTC), Date(2017, 2, 28, 0, 0, 0, 0, time.UTC), "P28D"},
+		{Date(2017, 1, 31, 0, 0, 0, 0, time.UTC), Date(2017, 3, 3, 0, 0, 0, 0, time.UTC), "P1M"},
+		{Date(2017, 3, 3, 0, 0, 0, 0, time.UTC), Date(2017, 1, 31, 0, 0, 0, 0, time.UTC), "-P1M3D"},
+		{Date(2017, 4, 24, 10, 0, 0, 0, time.UTC), Date(2017, 4, 24, 9, 0, 0, 0, time.UTC), "-PT1H"},
+		{Date(2017, 4, 24, 9, 0, 0, 0, time.UTC), Date(2017, 4, 24, 9, 0, 0, 1, time.UTC), "PT0.000000001S"},
+		{Date(2017, 4, 24, 9, 0, 0, 0, time.UTC), Date(2017, 4, 24, 9, 0, 0, 0, time.UTC), "PT0S"},
+		{Date(2017, 4, 24, 9, 0, 0, 0, time.UTC), Date(2017, 4, 24, 10, 0, 0, 0, time.FixedZone("+01:00", 3600)), "PT0S"},
+		{Date(2017, 3, 11, 12, 0, 0, 0, ny), Date(2017, 3, 12, 12, 0, 0, 0, ny), "P1D"},
+		{Date(2017, 3, 11, 12, 0, 0, 0, ny), Date(2017, 3, 12, 11, 0, 0, 0, ny), "PT22H"},
+		// adding the months alone gives 2015-03-08T02:31:28, which is in a DST gap
+		{Date(2037, 1, 8, 2, 31, 28, 0, ny), Date(2015, 2, 24, 15, 6, 10, 0, ny), "-P21Y10M11DT11H25M18S"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.from.String()+"/"+c.to.String(), func(t *testing.T) {
+			p := c.from.PeriodUntil(c.to)
+			expect.String(p.String()).ToBe(t, c.expected)
+			expect.Any(c.from.AddPeriod(p)).ToBe(t, c.to)
+		})
+	}
+}
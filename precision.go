@@ -0,0 +1,221 @@
+package iso8601
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Precision indicates the lowest-order component that is present in a date-time. For example,
+// "2017-04" has month precision and "2017-04-24T09:41Z" has minute precision.
+type Precision uint8
+
+const (
+	// PrecisionUnspecified is the zero value. A PreciseTime with unspecified precision is
+	// marshaled using MarshalTextFormat, as a Time is.
+	PrecisionUnspecified Precision = iota
+	PrecisionCentury
+	PrecisionYear
+	PrecisionMonth
+	PrecisionWeek
+	PrecisionDay
+	PrecisionHour
+	PrecisionMinute
+	PrecisionSecond
+	PrecisionFraction
+)
+
+var precisionNames = [...]string{
+	"unspecified",
+	"century",
+	"year",
+	"month",
+	"week",
+	"day",
+	"hour",
+	"minute",
+	"second",
+	"fraction",
+}
+
+// precisionLayouts holds the reference layouts used for marshaling each precision.
+// Century and week precision need special treatment; fraction uses MarshalTextFormat.
+var precisionLayouts = [...]string{
+	PrecisionYear:   "2006",
+	PrecisionMonth:  "2006-01",
+	PrecisionDay:    "2006-01-02",
+	PrecisionHour:   "2006-01-02T15Z07:00",
+	PrecisionMinute: "2006-01-02T15:04Z07:00",
+	PrecisionSecond: RFC3339,
+}
+
+func (p Precision) String() string {
+	if int(p) < len(precisionNames) {
+		return precisionNames[p]
+	}
+	return "Precision(" + strconv.Itoa(int(p)) + ")"
+}
+
+// PreciseTime is a Time that carries the precision with which it was written, so that
+// MarshalText and MarshalJSON render it with the same granularity, e.g. 2017-04 rather than
+// 2017-04-01T00:00:00Z. It also records whether it was parsed from 24:00 or from a leap second
+// and, if parsed by a Parser with WithRetainText, the style in which it was written.
+// It is returned by ParseWithPrecision. It is unmarshaled in the same way, except that it
+// also keeps its style, so that it is marshaled again as it was written.
+//
+// The methods of Time that return a new Time, such as Add and In, do not preserve any of this.
+type PreciseTime struct {
+	Time
+	precision  Precision
+	endOfDay   bool // parsed from 24:00 at the end of the previous day
+	marshal24  bool // endOfDay is rendered as 24:00; see Parser.WithMarshalEndOfDay
+	leapSecond bool // parsed from second 60
+	style      Formatter
+	styled     bool // the style was retained; see Parser.WithRetainText
+}
+
+var _ json.Unmarshaler = &PreciseTime{}
+
+// WithPrecision returns t with precision p. This controls how MarshalText and MarshalJSON
+// render the time; it does not alter the time instant.
+func (t Time) WithPrecision(p Precision) PreciseTime {
+	return PreciseTime{Time: t, precision: p}
+}
+
+// WithStyle returns t as a PreciseTime that MarshalText and MarshalJSON render using the
+// formatter f. Unlike Time.MarshalText, a Formatter renders years outside the range 0-9999
+// as ISO-8601 expanded years, e.g. -000044-03-15T00:00:00Z, so this allows such times to be
+// marshaled.
+func (t Time) WithStyle(f Formatter) PreciseTime {
+	return PreciseTime{Time: t, style: f, styled: true}
+}
+
+// WithStyle returns a copy of t that MarshalText and MarshalJSON render using the formatter
+// f, instead of according to its precision; see Time.WithStyle.
+func (t PreciseTime) WithStyle(f Formatter) PreciseTime {
+	t.style, t.styled = f, true
+	return t
+}
+
+// WithPrecision returns a copy of t that carries precision p. This controls how
+// MarshalText and MarshalJSON render the time; it does not alter the time instant.
+// The copy does not keep the style in which t was written (see Parser.WithRetainText).
+func (t PreciseTime) WithPrecision(p Precision) PreciseTime {
+	t.precision = p
+	t.styled = false
+	return t
+}
+
+// Precision returns the precision carried by t. This is PrecisionUnspecified unless
+// t was obtained from ParseWithPrecision or WithPrecision.
+func (t PreciseTime) Precision() Precision {
+	return t.precision
+}
+
+// IsEndOfDay reports whether t was parsed from 24:00, i.e. the end of the previous day.
+// Such a time is midnight at the start of the next day.
+func (t PreciseTime) IsEndOfDay() bool {
+	return t.endOfDay
+}
+
+// Style returns a Formatter that renders times in the style in which t was written, if it was
+// parsed by a Parser with WithRetainText. Otherwise, it returns false.
+func (t PreciseTime) Style() (Formatter, bool) {
+	return t.style, t.styled
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The time is formatted as for Time.MarshalText, except that lower-order components
+// are omitted according to the precision.
+func (t PreciseTime) MarshalText() ([]byte, error) {
+	b, ok := t.appendText(make([]byte, 0, len(MarshalTextFormat)+3))
+	if !ok {
+		return nil, errYearRange("PreciseTime.MarshalText", t.Year())
+	}
+	return b, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The time is formatted as for Time.MarshalJSON, except that lower-order components
+// are omitted according to the precision.
+func (t PreciseTime) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, len(MarshalTextFormat)+5)
+	b = append(b, '"')
+	b, ok := t.appendText(b)
+	if !ok {
+		return nil, errYearRange("PreciseTime.MarshalJSON", t.Year())
+	}
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The time is parsed as by ParseWithPrecision, and its style is retained (see
+// Parser.WithRetainText).
+func (t *PreciseTime) UnmarshalText(data []byte) (err error) {
+	*t, err = retainingParser().ParseWithPrecision(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a PreciseTime, as UnmarshalText does.
+func (t *PreciseTime) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*t, err = retainingParser().ParseWithPrecision(b)
+	return err
+}
+
+// appendText renders t using MarshalTextFormat. It returns false if the year is outside
+// the range 0-9999.
+func (t Time) appendText(b []byte) ([]byte, bool) {
+	return PreciseTime{Time: t}.appendText(b)
+}
+
+// appendText renders t according to its precision, or in its style if it has one. Without
+// a style, it returns false if the year is outside the range 0-9999.
+func (t PreciseTime) appendText(b []byte) ([]byte, bool) {
+	if t.styled {
+		return t.style.AppendTo(b, t.Time), true
+	}
+
+	layout := MarshalTextFormat
+	switch t.precision {
+	case PrecisionUnspecified, PrecisionFraction, PrecisionCentury, PrecisionWeek:
+	default:
+		layout = precisionLayouts[t.precision]
+	}
+
+	// midnight at the start of a day can be rendered as 24:00 at the end of the previous day
+	tm := t.Time.Time
+	endOfDay := t.endOfDay && t.marshal24 && strings.IndexByte(layout, 'T') >= 0
+	if endOfDay {
+		tm = tm.AddDate(0, 0, -1)
+	}
+
+	// RFC 3339 requires years to have exactly four digits; see golang.org/issue/4556#c15
+	if y := tm.Year(); y < 0 || y >= 10000 {
+		return nil, false
+	}
+
+	switch t.precision {
+	case PrecisionCentury:
+		return appendInt(b, tm.Year()/100, 2), true
+	case PrecisionWeek:
+		w := WeekOf(t.Time)
+		b = appendInt(b, w.Year, 4)
+		b = append(b, '-', 'W')
+		return appendInt(b, w.Week, 2), true
+	}
+
+	n := len(b)
+	b = tm.AppendFormat(b, withOffsetSeconds(layout, tm))
+	if endOfDay {
+		// the hour follows the T
+		h := n + bytes.IndexByte(b[n:], 'T') + 1
+		b[h], b[h+1] = '2', '4'
+	}
+	return b, true
+}
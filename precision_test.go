@@ -0,0 +1,99 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseWithPrecision(t *testing.T) {
+	cases := []struct {
+		input     string
+		precision Precision
+	}{
+		{"20", PrecisionCentury},
+		{"2017", PrecisionYear},
+		{"2017-04", PrecisionMonth},
+		{"2017-W17", PrecisionWeek},
+		{"2017W17", PrecisionWeek},
+		{"2017-04-24", PrecisionDay},
+		{"2017-04-24T", PrecisionDay},
+		{"2017-W17-1", PrecisionDay},
+		{"2017-114", PrecisionDay},
+		{"2017-04-24T09Z", PrecisionHour},
+		{"2017-04-24T09:41+01:00", PrecisionMinute},
+		{"20170424T0941", PrecisionMinute},
+		{"2017-04-24T09:41:34", PrecisionSecond},
+		{"20170424T094134Z", PrecisionSecond},
+		{"2017-04-24T09:41:34.502Z", PrecisionFraction},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tm, err := ParseWithPrecision([]byte(c.input))
+			expect.Any(tm.Precision(), err).ToBe(t, c.precision)
+		})
+	}
+
+	tm, err := ParseWithPrecision([]byte("2017-13"))
+	expect.Error(err).ToContain(t, "month 13 is not in range 1-12")
+	expect.Any(tm.Precision()).ToBe(t, PrecisionUnspecified)
+}
+
+func TestPrecision_String(t *testing.T) {
+	expect.String(PrecisionCentury.String()).ToBe(t, "century")
+	expect.String(PrecisionFraction.String()).ToBe(t, "fraction")
+	expect.String(Precision(99).String()).ToBe(t, "Precision(99)")
+}
+
+func TestTime_Marshaling_precision(t *testing.T) {
+	cases := []string{
+		"20",
+		"2017",
+		"2017-04",
+		"2017-W17",
+		"2017-04-24",
+		"2017-04-24T09Z",
+		"2017-04-24T09:41+01:00",
+		"2017-04-24T09:41:34Z",
+		"2017-04-24T09:41:34.502Z",
+		"1930-07-08T12:00+01:19:32",
+		"1930-07-08T12:00:00+01:19:32",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			tm, err := ParseWithPrecision([]byte(c))
+			expect.Error(err).Not().ToHaveOccurred(t)
+
+			b, err := tm.MarshalText()
+			expect.String(b, err).ToEqual(t, c)
+
+			b, err = json.Marshal(tm)
+			expect.String(b, err).ToEqual(t, `"`+c+`"`)
+		})
+	}
+
+	t.Run("unmarshaling", func(t *testing.T) {
+		var v struct {
+			P PreciseTime
+			T Time
+		}
+		err := json.Unmarshal([]byte(`{"P":"2017-04","T":"2017-04"}`), &v)
+		expect.Any(v.P.Precision(), err).ToBe(t, PrecisionMonth)
+
+		b, err := json.Marshal(v)
+		expect.String(b, err).ToEqual(t, `{"P":"2017-04","T":"2017-04-01T00:00:00Z"}`)
+
+		var p PreciseTime
+		err = p.UnmarshalText([]byte("2017-W17"))
+		expect.Any(p.Precision(), err).ToBe(t, PrecisionWeek)
+	})
+
+	t.Run("arithmetic discards precision", func(t *testing.T) {
+		tm, _ := ParseWithPrecision([]byte("2017-04"))
+		b, err := tm.AddDate(0, 1, 0).MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-05-01T00:00:00Z")
+	})
+}
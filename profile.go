@@ -0,0 +1,282 @@
+package iso8601
+
+import "strconv"
+
+// Profile is a set of restrictions on the ISO-8601 representations that are accepted.
+// Several standards define such a subset of ISO-8601, and a Parser can be configured
+// to accept only one of them. Every profile other than ProfileISO8601 requires two digits
+// in each field of the month, day and time, and at least one decimal place in a fraction.
+type Profile uint8
+
+const (
+	// ProfileISO8601 accepts everything that Parse accepts. It is the zero value.
+	ProfileISO8601 Profile = iota
+
+	// ProfileISO8601Full requires a complete date (calendar, week or ordinal) and a time with
+	// seconds and a zone designator, in either basic or extended format, e.g.
+	// 2017-04-24T09:41:34+01:00 or 2017W171T094134.5Z.
+	ProfileISO8601Full
+
+	// ProfileRFC3339 requires the RFC 3339 internet date-time format, e.g.
+	// 2017-04-24T09:41:34.502+01:00. The T and Z may be lowercase. A space between the date
+	// and time is only accepted by a Parser that allows it; see Parser.WithSpaceSeparator.
+	// The offset -00:00, which ISO 8601 does not allow, is accepted as UTC; RFC 3339 uses it
+	// when the local offset is unknown.
+	ProfileRFC3339
+
+	// ProfileW3CDTF requires the W3C date and time format, which is a year (e.g. 2017), a year
+	// and month (e.g. 2017-04), a date (e.g. 2017-04-24) or a date and a time with minutes and a
+	// zone designator (e.g. 2017-04-24T09:41Z or 2017-04-24T09:41:34.5+01:00).
+	ProfileW3CDTF
+
+	// ProfileXMLSchemaDateTime requires the XML Schema dateTime format, e.g.
+	// 2017-04-24T09:41:34.5, in which the zone designator is optional. The end of the day,
+	// 24:00:00, is allowed, and years of more than four digits must not have leading zeros.
+	ProfileXMLSchemaDateTime
+
+	// ProfileHTML5 requires the HTML global date and time format, e.g. 2017-04-24T09:41+0100
+	// or 2017-04-24 09:41:34.502Z, which has minutes, at most three decimal places of
+	// seconds and a zone designator. A space between the date and time is allowed.
+	ProfileHTML5
+)
+
+// yearRule specifies which years a profile allows.
+type yearRule uint8
+
+const (
+	anyYear      yearRule = iota
+	fourDigits            // exactly four digits, without a sign
+	xmlYear               // at least four digits, without a plus sign or superfluous leading zeros
+	positiveYear          // at least four digits, without a sign, and not zero
+)
+
+// zoneForm is a set of the ways in which a zone designator can be written.
+type zoneForm uint8
+
+const (
+	zoneUTC      zoneForm = 1 << iota // Z
+	zoneHours                         // +hh
+	zoneBasic                         // +hhmm
+	zoneExtended                      // +hh:mm
+	zoneOther                         // +hh:mm:ss etc.
+
+	anyZone = zoneUTC | zoneHours | zoneBasic | zoneExtended | zoneOther
+)
+
+// profileRules holds the restrictions of a profile. A zero value means no restriction.
+type profileRules struct {
+	name      string
+	extended  bool // the basic format is not allowed
+	calendar  bool // week and ordinal dates are not allowed
+	year      yearRule
+	date      bool      // a complete date is required
+	time      bool      // a time is required
+	minTime   Precision // the lowest-order time component required, if there is a time
+	decimals  int       // the maximum number of decimal places, if fewer than maxDigits
+	fullStop  bool      // the decimal sign must be a full stop
+	zones     zoneForm  // the allowed zone designators
+	zoneNames string    // describes zones, for error messages
+	noZone    bool      // a time without a zone designator is allowed
+	endOfDay  bool      // 24:00 is allowed
+	lowercase bool      // t and z are allowed as well as T and Z
+	space     bool      // a space is allowed between the date and time
+	negZero   bool      // the offset -00:00 is allowed
+}
+
+var profiles = [...]profileRules{
+	ProfileISO8601: {
+		name: "ISO 8601",
+	},
+	ProfileISO8601Full: {
+		name:      "ISO 8601 full",
+		date:      true,
+		time:      true,
+		minTime:   PrecisionSecond,
+		zones:     anyZone,
+		zoneNames: "a zone designator",
+		endOfDay:  true,
+	},
+	ProfileRFC3339: {
+		name:      "RFC 3339",
+		extended:  true,
+		calendar:  true,
+		year:      fourDigits,
+		date:      true,
+		time:      true,
+		minTime:   PrecisionSecond,
+		fullStop:  true,
+		zones:     zoneUTC | zoneExtended,
+		zoneNames: "Z or ±hh:mm",
+		lowercase: true,
+		negZero:   true,
+	},
+	ProfileW3CDTF: {
+		name:      "W3CDTF",
+		extended:  true,
+		calendar:  true,
+		year:      fourDigits,
+		minTime:   PrecisionMinute,
+		fullStop:  true,
+		zones:     zoneUTC | zoneExtended,
+		zoneNames: "Z or ±hh:mm",
+	},
+	ProfileXMLSchemaDateTime: {
+		name:      "XML Schema dateTime",
+		extended:  true,
+		calendar:  true,
+		year:      xmlYear,
+		date:      true,
+		time:      true,
+		minTime:   PrecisionSecond,
+		fullStop:  true,
+		zones:     zoneUTC | zoneExtended,
+		zoneNames: "Z or ±hh:mm",
+		noZone:    true,
+		endOfDay:  true,
+	},
+	ProfileHTML5: {
+		name:      "HTML5",
+		extended:  true,
+		calendar:  true,
+		year:      positiveYear,
+		date:      true,
+		time:      true,
+		minTime:   PrecisionMinute,
+		decimals:  3,
+		fullStop:  true,
+		zones:     zoneUTC | zoneBasic | zoneExtended,
+		zoneNames: "Z, ±hhmm or ±hh:mm",
+		space:     true,
+	},
+}
+
+func (pr Profile) String() string {
+	if int(pr) < len(profiles) {
+		return profiles[pr].name
+	}
+	return "Profile(" + strconv.Itoa(int(pr)) + ")"
+}
+
+// check returns an *iso8601.SyntaxError if the scanned fields f are not allowed by the profile.
+// It is called before the fields are validated.
+func (pr Profile) check(inp []byte, f *fields) error {
+	if pr == ProfileISO8601 {
+		return nil
+	}
+
+	r := &profiles[pr]
+	fail := func(element, reason string) error {
+		return &SyntaxError{Value: string(inp), Element: element, Reason: r.name + " " + reason}
+	}
+
+	switch {
+	case f.basic && r.extended:
+		return fail("date", "requires the extended format")
+	case f.form != calendarDate && r.calendar:
+		return fail("date", "requires a calendar date")
+	}
+
+	if err := r.checkYear(inp, f, fail); err != nil {
+		return err
+	}
+
+	for field, n := range f.widths {
+		if n == 1 {
+			return fail(fieldNames[field], "requires two digits")
+		}
+	}
+
+	if f.p < PrecisionDay && r.date {
+		return fail("date", "requires a complete date")
+	}
+
+	if f.p < PrecisionHour {
+		if r.time {
+			return fail("time", "requires a time")
+		}
+		return nil
+	}
+
+	timePrecision := f.p
+	if f.decimal != 0 {
+		timePrecision = f.fractionOf
+	}
+
+	switch {
+	case timePrecision < r.minTime && r.minTime == PrecisionSecond:
+		return fail("time", "requires hours, minutes and seconds")
+	case timePrecision < r.minTime:
+		return fail("time", "requires hours and minutes")
+	case f.decimal != 0 && f.fractionOf != PrecisionSecond:
+		return fail("fraction", "allows a decimal fraction only of the seconds")
+	case f.decimal != 0 && f.fractionDigits == 0:
+		return fail("fraction", "requires at least one decimal place")
+	case f.decimal == ',' && r.fullStop:
+		return fail("fraction", "requires a full stop as the decimal sign")
+	case r.decimals > 0 && f.fractionDigits > r.decimals:
+		return fail("fraction", "allows at most "+strconv.Itoa(r.decimals)+" decimal places")
+	case f.h == 24 && !r.endOfDay:
+		return fail("hour", "does not allow 24:00")
+	case f.zone == nil && !r.noZone:
+		return fail("zone", "requires a zone designator")
+	case f.zone != nil && zoneFormOf(f.zone)&r.zones == 0:
+		return fail("zone", "requires "+r.zoneNames)
+	}
+
+	return nil
+}
+
+// checkYear checks the year against the profile's year rule.
+func (r *profileRules) checkYear(inp []byte, f *fields, fail func(string, string) error) error {
+	var sign byte
+	i := 0
+	if inp[0] == '+' || inp[0] == '-' {
+		sign, i = inp[0], 1
+	}
+	n := scanDigits(inp, i) - i
+
+	switch r.year {
+	case fourDigits:
+		if sign != 0 || n != 4 {
+			return fail("year", "requires a four-digit year")
+		}
+
+	case xmlYear:
+		switch {
+		case sign == '+':
+			return fail("year", "does not allow a plus sign")
+		case n < 4:
+			return fail("year", "requires at least four digits")
+		case n > 4 && inp[i] == '0':
+			return fail("year", "does not allow leading zeros in a year of more than four digits")
+		}
+
+	case positiveYear:
+		switch {
+		case sign != 0:
+			return fail("year", "does not allow a sign")
+		case n < 4:
+			return fail("year", "requires at least four digits")
+		case f.Y == 0:
+			return fail("year", "does not allow year 0")
+		}
+	}
+
+	return nil
+}
+
+// zoneFormOf classifies a zone designator that has been scanned by parseZone.
+func zoneFormOf(zone []byte) zoneForm {
+	if zone[0] == 'Z' {
+		return zoneUTC
+	}
+	switch {
+	case len(zone) == 3:
+		return zoneHours
+	case len(zone) == 5:
+		return zoneBasic
+	case len(zone) == 6 && zone[3] == ':':
+		return zoneExtended
+	}
+	return zoneOther
+}
@@ -0,0 +1,140 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestProfile_ok(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+
+	cases := []struct {
+		profile  Profile
+		input    string
+		expected Time
+	}{
+		{profile: ProfileISO8601Full, input: "2017-04-24T09:41:34+01:00", expected: Date(2017, 4, 24, 9, 41, 34, 0, plus1)},
+		{profile: ProfileISO8601Full, input: "20170424T094134,5+0100", expected: Date(2017, 4, 24, 9, 41, 34, 500_000_000, plus1)},
+		{profile: ProfileISO8601Full, input: "2017W171T094134Z", expected: Date(2017, 4, 24, 9, 41, 34, 0, time.UTC)},
+		{profile: ProfileISO8601Full, input: "2017-114T09:41:34+01", expected: Date(2017, 4, 24, 9, 41, 34, 0, plus1)},
+		{profile: ProfileISO8601Full, input: "2017-04-24T24:00:00Z", expected: Date(2017, 4, 25, 0, 0, 0, 0, time.UTC)},
+
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34.502+01:00", expected: Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1)},
+		{profile: ProfileRFC3339, input: "2017-04-24t09:41:34z", expected: Date(2017, 4, 24, 9, 41, 34, 0, time.UTC)},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34.123456789Z", expected: Date(2017, 4, 24, 9, 41, 34, 123456789, time.UTC)},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34-00:00", expected: Date(2017, 4, 24, 9, 41, 34, 0, time.UTC)},
+
+		{profile: ProfileW3CDTF, input: "2017", expected: Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{profile: ProfileW3CDTF, input: "2017-04", expected: Date(2017, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{profile: ProfileW3CDTF, input: "2017-04-24", expected: Date(2017, 4, 24, 0, 0, 0, 0, time.UTC)},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:41Z", expected: Date(2017, 4, 24, 9, 41, 0, 0, time.UTC)},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:41:34.5+01:00", expected: Date(2017, 4, 24, 9, 41, 34, 500_000_000, plus1)},
+
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41:34.5", expected: Date(2017, 4, 24, 9, 41, 34, 500_000_000, time.UTC)},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41:34+01:00", expected: Date(2017, 4, 24, 9, 41, 34, 0, plus1)},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T24:00:00Z", expected: Date(2017, 4, 25, 0, 0, 0, 0, time.UTC)},
+		{profile: ProfileXMLSchemaDateTime, input: "12017-04-24T09:41:34Z", expected: Date(12017, 4, 24, 9, 41, 34, 0, time.UTC)},
+
+		{profile: ProfileHTML5, input: "2017-04-24T09:41Z", expected: Date(2017, 4, 24, 9, 41, 0, 0, time.UTC)},
+		{profile: ProfileHTML5, input: "2017-04-24 09:41:34.502+0100", expected: Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1)},
+		{profile: ProfileHTML5, input: "2017-04-24T09:41:34+01:00", expected: Date(2017, 4, 24, 9, 41, 34, 0, plus1)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.profile.String()+" "+c.input, func(t *testing.T) {
+			tm, err := NewParser(c.profile).ParseString(c.input)
+			expect.Any(tm, err).ToBe(t, c.expected)
+		})
+	}
+}
+
+func TestProfile_error(t *testing.T) {
+	cases := []struct {
+		profile Profile
+		input   string
+		message string
+	}{
+		{profile: ProfileISO8601Full, input: "2017-04-24", message: `invalid time; ISO 8601 full requires a time`},
+		{profile: ProfileISO8601Full, input: "2017-04", message: `invalid date; ISO 8601 full requires a complete date`},
+		{profile: ProfileISO8601Full, input: "2017-04-24T09:41Z", message: `invalid time; ISO 8601 full requires hours, minutes and seconds`},
+		{profile: ProfileISO8601Full, input: "2017-04-24T09:41:34", message: `invalid zone; ISO 8601 full requires a zone designator`},
+		{profile: ProfileISO8601Full, input: "2017-04-24 09:41:34Z", message: `ISO 8601 full does not allow a space between the date and time`},
+		{profile: ProfileISO8601Full, input: "2017-04-24t09:41:34Z", message: `ISO 8601 full requires an upper-case T`},
+
+		{profile: ProfileRFC3339, input: "2017-04-24", message: `invalid time; RFC 3339 requires a time`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09", message: `invalid time; RFC 3339 requires hours, minutes and seconds`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41Z", message: `invalid time; RFC 3339 requires hours, minutes and seconds`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34", message: `invalid zone; RFC 3339 requires a zone designator`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34+01", message: `invalid zone; RFC 3339 requires Z or ±hh:mm`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34+0100", message: `invalid zone; RFC 3339 requires Z or ±hh:mm`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34,5Z", message: `invalid fraction; RFC 3339 requires a full stop as the decimal sign`},
+		{profile: ProfileRFC3339, input: "20170424T094134Z", message: `invalid date; RFC 3339 requires the extended format`},
+		{profile: ProfileRFC3339, input: "2017-W17-1T09:41:34Z", message: `invalid date; RFC 3339 requires a calendar date`},
+		{profile: ProfileRFC3339, input: "2017-114T09:41:34Z", message: `invalid date; RFC 3339 requires a calendar date`},
+		{profile: ProfileRFC3339, input: "+002017-04-24T09:41:34Z", message: `invalid year; RFC 3339 requires a four-digit year`},
+		{profile: ProfileRFC3339, input: "12017-04-24T09:41:34Z", message: `invalid year; RFC 3339 requires a four-digit year`},
+		{profile: ProfileRFC3339, input: "2017-04-24T24:00:00Z", message: `invalid hour; RFC 3339 does not allow 24:00`},
+		{profile: ProfileRFC3339, input: "2017-04-24 09:41:34Z", message: `invalid date-time at ' '; RFC 3339 does not allow a space between the date and time`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34Z[Europe/London]", message: `invalid suffix; RFC 3339 does not allow a suffix`},
+		{profile: ProfileRFC3339, input: "2017-04-31T09:41:34Z", message: `day 31 is not in range 1-30`},
+
+		{profile: ProfileW3CDTF, input: "17", message: `invalid year; W3CDTF requires a four-digit year`},
+		{profile: ProfileW3CDTF, input: "2017-W17", message: `invalid date; W3CDTF requires a calendar date`},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09Z", message: `invalid time; W3CDTF requires hours and minutes`},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:41.5Z", message: `invalid fraction; W3CDTF allows a decimal fraction only of the seconds`},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:41", message: `invalid zone; W3CDTF requires a zone designator`},
+
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24", message: `invalid time; XML Schema dateTime requires a time`},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41", message: `invalid time; XML Schema dateTime requires hours, minutes and seconds`},
+		{profile: ProfileXMLSchemaDateTime, input: "+002017-04-24T09:41:34", message: `invalid year; XML Schema dateTime does not allow a plus sign`},
+		{profile: ProfileXMLSchemaDateTime, input: "02017-04-24T09:41:34", message: `invalid year; XML Schema dateTime does not allow leading zeros in a year of more than four digits`},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41:34+01", message: `invalid zone; XML Schema dateTime requires Z or ±hh:mm`},
+
+		{profile: ProfileHTML5, input: "2017-04-24T09:41:34.5021Z", message: `invalid fraction; HTML5 allows at most 3 decimal places`},
+		{profile: ProfileHTML5, input: "0000-04-24T09:41Z", message: `invalid year; HTML5 does not allow year 0`},
+		{profile: ProfileHTML5, input: "2017-04-24T09:41+01", message: `invalid zone; HTML5 requires Z, ±hhmm or ±hh:mm`},
+		{profile: ProfileHTML5, input: "2017-04-24T09:41", message: `invalid zone; HTML5 requires a zone designator`},
+		{profile: ProfileHTML5, input: "2017-04-24T09:41z", message: `HTML5 requires an upper-case Z`},
+
+		// fields must have two digits and fractions must have at least one
+		{profile: ProfileISO8601Full, input: "2017-4-24T09:41:34Z", message: `invalid month; ISO 8601 full requires two digits`},
+		{profile: ProfileISO8601Full, input: "2017-04-24T09:41:34.Z", message: `invalid fraction; ISO 8601 full requires at least one decimal place`},
+		{profile: ProfileRFC3339, input: "2017-4-24T9:41:34Z", message: `invalid month; RFC 3339 requires two digits`},
+		{profile: ProfileRFC3339, input: "2017-04-24T9:41:34Z", message: `invalid hour; RFC 3339 requires two digits`},
+		{profile: ProfileRFC3339, input: "2017-04-24T09:41:34.Z", message: `invalid fraction; RFC 3339 requires at least one decimal place`},
+		{profile: ProfileW3CDTF, input: "2017-4", message: `invalid month; W3CDTF requires two digits`},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:4Z", message: `invalid minute; W3CDTF requires two digits`},
+		{profile: ProfileW3CDTF, input: "2017-04-24T09:41:34.Z", message: `invalid fraction; W3CDTF requires at least one decimal place`},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-4T09:41:34", message: `invalid day; XML Schema dateTime requires two digits`},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41:4", message: `invalid second; XML Schema dateTime requires two digits`},
+		{profile: ProfileXMLSchemaDateTime, input: "2017-04-24T09:41:34.", message: `invalid fraction; XML Schema dateTime requires at least one decimal place`},
+		{profile: ProfileHTML5, input: "2017-04-24T9:41Z", message: `invalid hour; HTML5 requires two digits`},
+		{profile: ProfileHTML5, input: "2017-04-24T09:41:34.Z", message: `invalid fraction; HTML5 requires at least one decimal place`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.profile.String()+" "+c.input, func(t *testing.T) {
+			_, err := NewParser(c.profile).ParseString(c.input)
+			expect.Error(err).ToContain(t, `Cannot parse "`+c.input+`"`)
+			expect.Error(err).ToContain(t, c.message)
+		})
+	}
+}
+
+func TestProfile_negativeZero(t *testing.T) {
+	// only RFC 3339 allows -00:00, and only in extended format
+	_, err := NewParser(ProfileRFC3339).ParseString("2017-04-24T09:41:34-0000")
+	expect.Error(err).ToContain(t, `Cannot parse "-0000": invalid zone`)
+	for _, profile := range []Profile{ProfileISO8601, ProfileISO8601Full, ProfileXMLSchemaDateTime, ProfileHTML5} {
+		_, err = NewParser(profile).ParseString("2017-04-24T09:41:34-00:00")
+		expect.Error(err).ToContain(t, `Cannot parse "-00:00": invalid zone`)
+	}
+}
+
+func TestProfile_String(t *testing.T) {
+	expect.String(ProfileISO8601.String()).ToBe(t, "ISO 8601")
+	expect.String(ProfileRFC3339.String()).ToBe(t, "RFC 3339")
+	expect.String(Profile(99).String()).ToBe(t, "Profile(99)")
+}
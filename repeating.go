@@ -0,0 +1,299 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"iter"
+	"math"
+	"sort"
+)
+
+// RepeatingInterval is an ISO-8601 recurring time interval, such as
+// R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M, which occurs five times, or
+// R/2024-01-01T00:00Z/P1W, which recurs without limit.
+//
+// Each occurrence lasts for the period of the interval. When the interval is expressed with
+// a start, the kth occurrence (counting from zero) begins k periods after the start. When it
+// is expressed as a period and an end, the last occurrence finishes at the end and the kth
+// occurrence before that finishes k periods before the end.
+//
+// The period should be positive. The zero value has no occurrences.
+type RepeatingInterval struct {
+	repeats  int // the number of occurrences, or -1 if unbounded
+	interval Interval
+	step     Period
+}
+
+var _ json.Unmarshaler = &RepeatingInterval{}
+
+// NewRepeatingInterval returns an interval that occurs n times. If n is negative,
+// it recurs without limit.
+func NewRepeatingInterval(n int, iv Interval) RepeatingInterval {
+	if n < 0 {
+		n = -1
+	}
+	return RepeatingInterval{repeats: n, interval: iv, step: iv.Period()}
+}
+
+// ParseRepeatingInterval parses an ISO-8601 recurring time interval, Rn/interval, where n is
+// the number of occurrences. If n is omitted, i.e. R/interval, the interval recurs without
+// limit. The interval is parsed using ParseInterval.
+func ParseRepeatingInterval(inp []byte) (RepeatingInterval, error) {
+	if len(inp) == 0 || inp[0] != 'R' {
+		return RepeatingInterval{}, errRepeatingSyntax(inp, 0)
+	}
+
+	j := scanDigits(inp, 1)
+	if j == len(inp) || inp[j] != '/' {
+		return RepeatingInterval{}, errRepeatingSyntax(inp, j)
+	}
+	if j-1 > maxDigits {
+		return RepeatingInterval{}, &SyntaxError{Value: string(inp), Element: "repeating interval", Reason: "too many digits"}
+	}
+
+	n := -1
+	if j > 1 {
+		n = atoi(inp[1:j])
+	}
+
+	iv, err := ParseInterval(inp[j+1:])
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	return NewRepeatingInterval(n, iv), nil
+}
+
+// ParseRepeatingIntervalString parses an ISO-8601 recurring time interval string;
+// see ParseRepeatingInterval.
+func ParseRepeatingIntervalString(inp string) (RepeatingInterval, error) {
+	return ParseRepeatingInterval([]byte(inp))
+}
+
+func errRepeatingSyntax(inp []byte, i int) error {
+	if i < len(inp) {
+		return &SyntaxError{Value: string(inp), Element: "repeating interval", Rune: rune(inp[i])}
+	}
+	return &SyntaxError{Value: string(inp), Element: "repeating interval"}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Repeats returns the number of occurrences, or -1 if the interval recurs without limit.
+func (r RepeatingInterval) Repeats() int {
+	return r.repeats
+}
+
+// Interval returns the interval that recurs.
+func (r RepeatingInterval) Interval() Interval {
+	return r.interval
+}
+
+// occurrence returns the start of the kth occurrence, counting from the start of the interval,
+// or backwards from the end if the interval is expressed as a period and an end. Each is
+// calculated directly from the interval using a multiple of the period, so that calendar
+// normalization does not accumulate, e.g. monthly occurrences from January 31st return to
+// the 31st in each month that has one. k must be less than limit.
+func (r RepeatingInterval) occurrence(k int) Time {
+	if r.interval.form == periodEnd {
+		p, _ := r.step.scale(k + 1)
+		return r.interval.end.SubPeriod(p)
+	}
+	p, _ := r.step.scale(k)
+	return r.interval.start.AddPeriod(p)
+}
+
+// limit returns the number of occurrences that can be calculated: the number of repeats if
+// the interval is bounded, otherwise as many as the period can be multiplied without overflow.
+func (r RepeatingInterval) limit() int {
+	n := r.step.maxScale()
+	if r.interval.form == periodEnd {
+		n--
+	}
+	if n < math.MaxInt {
+		n++
+	}
+	if r.repeats >= 0 {
+		n = min(n, r.repeats)
+	}
+	return n
+}
+
+// All returns an iterator over the start of each occurrence, in chronological order. The
+// exception is an unbounded interval expressed as a period and an end, for which there is no
+// earliest occurrence: it yields the latest occurrence first, then each earlier one in turn.
+// An unbounded interval stops when the multiple of the period would overflow.
+func (r RepeatingInterval) All() iter.Seq[Time] {
+	return func(yield func(Time) bool) {
+		n := r.limit()
+		if r.interval.form == periodEnd && r.repeats > 0 {
+			for k := n - 1; k >= 0; k-- {
+				if !yield(r.occurrence(k)) {
+					return
+				}
+			}
+			return
+		}
+
+		for k := 0; k < n; k++ {
+			if !yield(r.occurrence(k)) {
+				return
+			}
+		}
+	}
+}
+
+// Next returns the start of the first occurrence after the given time. It returns false if there
+// is no such occurrence. This does not iterate through the earlier occurrences, so it is quick
+// even when there are very many of them.
+func (r RepeatingInterval) Next(after Time) (Time, bool) {
+	n := r.limit()
+	if n == 0 {
+		return Time{}, false
+	}
+
+	// occurrences are ascending in k when d > 0 and descending when d < 0
+	s0 := r.occurrence(0)
+	d := 0.0
+	if n > 1 {
+		d = secondsBetween(s0, r.occurrence(1))
+	}
+
+	if d == 0 {
+		if s0.After(after) {
+			return s0, true
+		}
+		return Time{}, false
+	}
+
+	k := r.estimate(s0, d, after, n)
+
+	if d > 0 {
+		// find the least k whose occurrence is after the given time
+		k = search(n, k, func(k int) bool { return r.occurrence(k).After(after) })
+		if k == n {
+			return Time{}, false
+		}
+		return r.occurrence(k), true
+	}
+
+	// find the greatest k whose occurrence is after the given time
+	k = search(n, k, func(k int) bool { return !r.occurrence(k).After(after) }) - 1
+	if k < 0 {
+		return Time{}, false
+	}
+	return r.occurrence(k), true
+}
+
+// estimate returns an approximate index of the occurrence at the given time, which is within
+// the range [0,n). The first occurrence is s0 and d is the length in seconds of the first
+// step. Because the period can have months and years, whose lengths vary, a second estimate
+// is made using the average step length up to the first estimate.
+func (r RepeatingInterval) estimate(s0 Time, d float64, at Time, n int) int {
+	diff := secondsBetween(s0, at)
+
+	clamp := func(k float64) int {
+		if k < 0 {
+			return 0
+		}
+		if k >= float64(n-1) {
+			return n - 1
+		}
+		return int(k)
+	}
+
+	k := clamp(diff / d)
+	if k > 1 {
+		if avg := secondsBetween(s0, r.occurrence(k)) / float64(k); avg != 0 {
+			k = clamp(diff / avg)
+		}
+	}
+	return k
+}
+
+// search returns the least k in [0,n) for which f is true, or n if there is none. f must be
+// false and then true as k increases. The search gallops outwards from the estimate k and then
+// bisects, so it takes only a few steps when the estimate is close.
+func search(n, k int, f func(int) bool) int {
+	lo, hi := 0, n // f is false below lo and true from hi
+	if f(k) {
+		hi = k
+		for step := 1; step <= k; step *= 2 {
+			if !f(k - step) {
+				lo = k - step + 1
+				break
+			}
+			hi = k - step
+		}
+	} else {
+		lo = k + 1
+		for step := 1; step < n-k; step *= 2 {
+			if f(k + step) {
+				hi = k + step
+				break
+			}
+			lo = k + step + 1
+		}
+	}
+	return lo + sort.Search(hi-lo, func(i int) bool { return f(lo + i) })
+}
+
+// secondsBetween returns u-t in seconds, without the range limit of time.Duration.
+func secondsBetween(t, u Time) float64 {
+	return float64(u.Unix()-t.Unix()) + float64(u.Nanosecond()-t.Nanosecond())/1e9
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String renders the repeating interval in ISO-8601 format, e.g. R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M.
+func (r RepeatingInterval) String() string {
+	b, _ := r.appendText(make([]byte, 0, 72))
+	return string(b)
+}
+
+func (r RepeatingInterval) appendText(b []byte) ([]byte, bool) {
+	b = append(b, 'R')
+	if r.repeats >= 0 {
+		b = appendInt(b, r.repeats, 1)
+	}
+	b = append(b, '/')
+	return r.interval.appendText(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The repeating interval is formatted in ISO-8601 format.
+func (r RepeatingInterval) MarshalText() ([]byte, error) {
+	b, ok := r.appendText(make([]byte, 0, 72))
+	if !ok {
+		return nil, errYearRange("RepeatingInterval.MarshalText", r.interval.outOfRangeYear())
+	}
+	return b, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The repeating interval is a quoted string in ISO-8601 format.
+func (r RepeatingInterval) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 74)
+	b = append(b, '"')
+	b, ok := r.appendText(b)
+	if !ok {
+		return nil, errYearRange("RepeatingInterval.MarshalJSON", r.interval.outOfRangeYear())
+	}
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The repeating interval is expected to be in ISO-8601 format; see ParseRepeatingInterval.
+func (r *RepeatingInterval) UnmarshalText(data []byte) (err error) {
+	*r, err = ParseRepeatingInterval(data)
+	return err
+}
+
+// UnmarshalJSON decodes a JSON string or null into a repeating interval.
+func (r *RepeatingInterval) UnmarshalJSON(b []byte) error {
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
+	}
+	*r, err = ParseRepeatingInterval(b)
+	return err
+}
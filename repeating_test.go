@@ -0,0 +1,193 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseRepeatingInterval_ok(t *testing.T) {
+	cases := []struct {
+		input   string
+		repeats int
+		first   []Time
+	}{
+		{
+			input:   "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			repeats: 5,
+			first: []Time{
+				Date(2008, 3, 1, 13, 0, 0, 0, time.UTC),
+				Date(2009, 5, 11, 15, 30, 0, 0, time.UTC),
+				Date(2010, 7, 21, 18, 0, 0, 0, time.UTC),
+				Date(2011, 10, 1, 20, 30, 0, 0, time.UTC),
+				Date(2012, 12, 11, 23, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:   "R/2024-01-01T00:00Z/P1W",
+			repeats: -1,
+			first: []Time{
+				Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+				Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:   "R3/2024-01-31/2024-02-29",
+			repeats: 3,
+			first: []Time{
+				Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:   "R3/P1D/2024-01-10",
+			repeats: 3,
+			first: []Time{
+				Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+				Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+				Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:   "R/P1D/2024-01-10",
+			repeats: -1,
+			first: []Time{
+				Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+				Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:   "R0/2024-01-01/P1D",
+			repeats: 0,
+			first:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			r, err := ParseRepeatingIntervalString(c.input)
+			expect.Error(err).ToBeNil(t)
+			expect.Number(r.Repeats()).ToBe(t, c.repeats)
+			expect.String(r.String()).ToBe(t, c.input)
+
+			var got []Time
+			for s := range r.All() {
+				if len(got) == len(c.first) {
+					break
+				}
+				got = append(got, s)
+			}
+			expect.Slice(got).ToBe(t, c.first...)
+
+			if c.repeats >= 0 {
+				expect.Number(len(slices.Collect(r.All()))).ToBe(t, c.repeats)
+			}
+		})
+	}
+}
+
+func TestParseRepeatingInterval_error(t *testing.T) {
+	cases := map[string]string{
+		"":                           `Cannot parse "": invalid repeating interval`,
+		"2024-01-01/P1D":             `Cannot parse "2024-01-01/P1D": invalid repeating interval at '2'`,
+		"R":                          `Cannot parse "R": invalid repeating interval`,
+		"R5":                         `Cannot parse "R5": invalid repeating interval`,
+		"R5x/2024-01-01/P1D":         `Cannot parse "R5x/2024-01-01/P1D": invalid repeating interval at 'x'`,
+		"R1234567890/2024-01-01/P1D": `Cannot parse "R1234567890/2024-01-01/P1D": invalid repeating interval; too many digits`,
+		"R5/2024-01-01":              `Cannot parse "2024-01-01": invalid interval`,
+	}
+
+	for input, msg := range cases {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRepeatingIntervalString(input)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestRepeatingInterval_Next(t *testing.T) {
+	monthly := NewRepeatingInterval(-1, IntervalFrom(Date(2000, 1, 31, 9, 0, 0, 0, time.UTC), NewPeriod(0, 1, 0, 0, 0, 0, 0)))
+	bounded := NewRepeatingInterval(5, IntervalFrom(Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), NewPeriod(0, 0, 0, 1, 0, 0, 0)))
+	backwards := NewRepeatingInterval(-1, IntervalTo(NewPeriod(0, 0, 1, 0, 0, 0, 0), Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)))
+
+	cases := []struct {
+		r        RepeatingInterval
+		after    Time
+		expected Time
+		ok       bool
+	}{
+		{monthly, Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), Date(2000, 1, 31, 9, 0, 0, 0, time.UTC), true},
+		{monthly, Date(2000, 1, 31, 9, 0, 0, 0, time.UTC), Date(2000, 3, 2, 9, 0, 0, 0, time.UTC), true},
+		{monthly, Date(2000, 3, 2, 9, 0, 0, 0, time.UTC), Date(2000, 3, 31, 9, 0, 0, 0, time.UTC), true},
+		{monthly, Date(2800, 5, 2, 0, 0, 0, 0, time.UTC), Date(2800, 5, 31, 9, 0, 0, 0, time.UTC), true},
+		{bounded, Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), true},
+		{bounded, Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Time{}, false},
+		{backwards, Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), true},
+		{backwards, Date(1990, 1, 10, 0, 0, 0, 0, time.UTC), Date(1990, 1, 15, 0, 0, 0, 0, time.UTC), true},
+		{backwards, Date(2024, 1, 22, 0, 0, 0, 0, time.UTC), Time{}, false},
+		{RepeatingInterval{}, Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Time{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.r.String()+" after "+c.after.String(), func(t *testing.T) {
+			next, ok := c.r.Next(c.after)
+			expect.Bool(ok).ToBe(t, c.ok)
+			expect.Any(next).ToBe(t, c.expected)
+		})
+	}
+}
+
+func TestRepeatingInterval_limits(t *testing.T) {
+	// about two billion occurrences before the given time
+	seconds := NewRepeatingInterval(-1, IntervalFrom(Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), NewPeriod(0, 0, 0, 0, 0, 0, 1)))
+	next, ok := seconds.Next(Date(2060, 1, 1, 0, 0, 0, 500_000_000, time.UTC))
+	expect.Bool(ok).ToBeTrue(t)
+	expect.Any(next).ToBe(t, Date(2060, 1, 1, 0, 0, 1, 0, time.UTC))
+
+	// the period can only be doubled without overflow
+	r, err := ParseRepeatingIntervalString("R/2000-01-01T00:00:00Z/PT9000000000S")
+	expect.Error(err).ToBeNil(t)
+	expect.Slice(slices.Collect(r.All())).ToBe(t, Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Date(2285, 3, 13, 16, 0, 0, 0, time.UTC))
+
+	next, ok = r.Next(Date(2100, 1, 1, 0, 0, 0, 0, time.UTC))
+	expect.Bool(ok).ToBeTrue(t)
+	expect.Any(next).ToBe(t, Date(2285, 3, 13, 16, 0, 0, 0, time.UTC))
+
+	_, ok = r.Next(Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+	expect.Bool(ok).ToBeFalse(t)
+
+	backwards, err := ParseRepeatingIntervalString("R/PT9000000000S/2285-03-13T16:00:00Z")
+	expect.Error(err).ToBeNil(t)
+	expect.Slice(slices.Collect(backwards.All())).ToBe(t, Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestRepeatingInterval_Marshaling(t *testing.T) {
+	r, err := ParseRepeatingIntervalString("R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M")
+	expect.Error(err).ToBeNil(t)
+
+	t.Run("text", func(t *testing.T) {
+		b, err := r.MarshalText()
+		expect.String(b, err).ToEqual(t, "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M")
+
+		var r2 RepeatingInterval
+		err = r2.UnmarshalText(b)
+		expect.Any(r2, err).ToBe(t, r)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(r)
+		expect.String(b, err).ToEqual(t, `"R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M"`)
+
+		var r2 RepeatingInterval
+		err = json.Unmarshal(b, &r2)
+		expect.Any(r2, err).ToBe(t, r)
+
+		expect.Any(r2.UnmarshalJSON([]byte(`R/P1D`))).ToBe(t, ErrNotString)
+		expect.Error(r2.UnmarshalJSON([]byte(`null`))).Not().ToHaveOccurred(t)
+	})
+}
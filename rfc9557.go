@@ -0,0 +1,297 @@
+package iso8601
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ZoneMismatchPolicy determines how an RFC 9557 time zone suffix is treated when it does not
+// agree with the zone offset, e.g. 2022-07-08T00:14:07+05:00[Europe/London].
+type ZoneMismatchPolicy uint8
+
+const (
+	// RejectZoneMismatch causes an *iso8601.SyntaxError.
+	RejectZoneMismatch ZoneMismatchPolicy = iota
+
+	// PreferOffset keeps the instant given by the date, time and offset, and expresses it
+	// in the suffix time zone, i.e. the local time is altered.
+	PreferOffset
+
+	// PreferZone keeps the local date and time and interprets them in the suffix time zone,
+	// i.e. the offset is ignored and the instant is altered.
+	PreferZone
+)
+
+// Tag is an RFC 9557 suffix tag, such as [u-ca=gregory]. A critical tag, written with a leading
+// exclamation mark (e.g. [!u-ca=gregory]), must be rejected by an application that does not
+// support it.
+type Tag struct {
+	Key      string
+	Value    string
+	Critical bool
+}
+
+// String renders the tag in its bracketed form, e.g. [u-ca=gregory].
+func (t Tag) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	if t.Critical {
+		b.WriteByte('!')
+	}
+	b.WriteString(t.Key)
+	b.WriteByte('=')
+	b.WriteString(t.Value)
+	b.WriteByte(']')
+	return b.String()
+}
+
+// isSupported reports whether the tag is understood by Parse. Only the calendar tag is, and
+// only for the ISO-8601 calendar, which is the proleptic Gregorian calendar.
+func (t Tag) isSupported() bool {
+	return t.Key == "u-ca" && (t.Value == "iso8601" || t.Value == "gregory")
+}
+
+// ParseRFC9557 parses an ISO-8601 date-time with an optional RFC 9557 suffix, which consists
+// of a time zone in brackets and/or tags in brackets, e.g.
+// 2022-07-08T00:14:07+01:00[Europe/London][u-ca=gregory].
+//
+// The time zone is an IANA time zone name, which is resolved using time.LoadLocation, or an
+// offset such as [+01:00]. The returned Time is in that location. If there is no zone offset,
+// the date and time are in that location; if the offset is Z, the instant is in UTC. Otherwise,
+// the offset must agree with the time zone (see Parser.WithZoneMismatch).
+//
+// The tags are returned in the order they were written. Unlike Parse, ParseRFC9557 does not
+// reject critical tags: the caller must reject any critical tag that it does not support.
+func ParseRFC9557(inp []byte) (Time, []Tag, error) {
+	return defaultParser().ParseRFC9557(inp)
+}
+
+// ParseRFC9557String parses an ISO-8601 date-time string with an optional RFC 9557 suffix;
+// see ParseRFC9557.
+func ParseRFC9557String(inp string) (Time, []Tag, error) {
+	return ParseRFC9557([]byte(inp))
+}
+
+// ParseRFC9557 is like the ParseRFC9557 function but uses the parser's settings. Only
+// ProfileISO8601 allows a suffix.
+func (p Parser) ParseRFC9557(inp []byte) (Time, []Tag, error) {
+	t, tags, err := p.parse(inp)
+	if err != nil {
+		return Time{}, nil, err
+	}
+	return t.Time, tags, nil
+}
+
+// parseWithSuffix parses the input, which is in the parser's location if it has no zone
+// designator and no time zone suffix. The suffix, if any, starts at inp[k].
+func (p Parser) parseWithSuffix(inp []byte, k int) (PreciseTime, []Tag, error) {
+	if k < 0 {
+		t, err := p.parseDateTime(inp, p.Location())
+		return t, nil, err
+	}
+
+	zone, critical, tags, err := parseSuffix(inp, k)
+	if err != nil {
+		return PreciseTime{}, nil, err
+	}
+
+	if zone == nil {
+		t, err := p.parseDateTime(inp[:k], p.Location())
+		return t, tags, err
+	}
+
+	t, err := p.parseDateTime(inp[:k], zone)
+	if err != nil {
+		return PreciseTime{}, nil, err
+	}
+	t.style.suffix = t.styled
+
+	// if the location is not the zone, there was an offset
+	if t.Location() != zone {
+		_, offset := t.Zone()
+		_, zoneOffset := t.Time.Time.In(zone).Zone()
+
+		switch {
+		case inp[k-1] == 'Z' || offset == zoneOffset:
+			t.Time = t.In(zone)
+
+		case critical || p.zoneMismatch == RejectZoneMismatch:
+			return PreciseTime{}, nil, &SyntaxError{Value: string(inp), Element: "suffix", Reason: "offset " + t.Format("-07:00") + " does not agree with " + zone.String()}
+
+		case p.zoneMismatch == PreferOffset:
+			t.Time = t.In(zone)
+
+		default: // PreferZone
+			y, m, d := t.Date()
+			hh, mm, ss := t.Clock()
+			t.Time = Date(y, m, d, hh, mm, ss, t.Nanosecond(), zone)
+		}
+	}
+
+	return t, tags, nil
+}
+
+// parseSuffix scans the RFC 9557 suffix starting at inp[i], which is the first '['. It returns
+// the time zone, or nil if there is none, whether the time zone is critical, and the tags.
+func parseSuffix(inp []byte, i int) (*time.Location, bool, []Tag, error) {
+	var zone *time.Location
+	var zoneCritical bool
+	var tags []Tag
+
+	for i < len(inp) {
+		if inp[i] != '[' {
+			return nil, false, nil, errSuffixSyntax(inp, i)
+		}
+
+		j := bytes.IndexByte(inp[i:], ']')
+		if j < 0 {
+			return nil, false, nil, &SyntaxError{Value: string(inp), Element: "suffix", Reason: "missing ']'"}
+		}
+		j += i
+
+		content := inp[i+1 : j]
+		critical := len(content) > 0 && content[0] == '!'
+		if critical {
+			content = content[1:]
+		}
+
+		if eq := bytes.IndexByte(content, '='); eq >= 0 {
+			key, value := content[:eq], content[eq+1:]
+			if !isTagKey(key) || !isTagValue(value) {
+				return nil, false, nil, &SyntaxError{Value: string(inp), Element: "suffix", Reason: "invalid tag"}
+			}
+			tags = append(tags, Tag{Key: string(key), Value: string(value), Critical: critical})
+
+		} else {
+			// the time zone must come first
+			if zone != nil || len(tags) > 0 || len(content) == 0 {
+				return nil, false, nil, errSuffixSyntax(inp, i)
+			}
+
+			var err error
+			if zone, err = parseSuffixZone(inp, content); err != nil {
+				return nil, false, nil, err
+			}
+			zoneCritical = critical
+		}
+
+		i = j + 1
+	}
+
+	return zone, zoneCritical, tags, nil
+}
+
+// parseSuffixZone resolves a time zone suffix, which is either an offset or an IANA time zone name.
+func parseSuffixZone(inp, name []byte) (*time.Location, error) {
+	if name[0] == '+' || name[0] == '-' {
+		return ParseISOZone(name)
+	}
+
+	for _, c := range name {
+		if !isAlphaNum(c) && c != '/' && c != '_' && c != '-' && c != '+' && c != '.' {
+			return nil, &SyntaxError{Value: string(inp), Element: "time zone", Rune: rune(c)}
+		}
+	}
+
+	loc, err := time.LoadLocation(string(name))
+	if err != nil {
+		return nil, &SyntaxError{Value: string(inp), Element: "time zone", Reason: "unknown time zone " + string(name)}
+	}
+	return loc, nil
+}
+
+// isTagKey reports whether b is a suffix key, which starts with a lowercase letter or
+// underscore, followed by lowercase letters, digits, underscores or hyphens.
+func isTagKey(b []byte) bool {
+	if len(b) == 0 || !(('a' <= b[0] && b[0] <= 'z') || b[0] == '_') {
+		return false
+	}
+	for _, c := range b[1:] {
+		if !(('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// isTagValue reports whether b is a suffix value, which is one or more runs of letters and
+// digits separated by hyphens.
+func isTagValue(b []byte) bool {
+	if len(b) == 0 || b[0] == '-' || b[len(b)-1] == '-' {
+		return false
+	}
+	for i, c := range b {
+		if !isAlphaNum(c) && !(c == '-' && b[i-1] != '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func errSuffixSyntax(inp []byte, i int) error {
+	return &SyntaxError{Value: string(inp), Element: "suffix", Rune: rune(inp[i])}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// FormatRFC9557 renders t as MarshalText would, followed by an RFC 9557 time zone suffix if
+// t has a named location, e.g. 2022-07-08T00:14:07+01:00[Europe/London]. A location is named
+// if its name is an IANA time zone that time.LoadLocation can load; UTC, Local and fixed
+// zones have no suffix. If t cannot be marshaled, its String form is used instead.
+func (t Time) FormatRFC9557() string {
+	b, err := t.AppendRFC9557(make([]byte, 0, 48))
+	if err != nil {
+		return t.String()
+	}
+	return string(b)
+}
+
+// AppendRFC9557 is like FormatRFC9557 but appends the textual representation to b
+// and returns the extended buffer. It returns an error in the same cases as MarshalText.
+func (t Time) AppendRFC9557(b []byte) ([]byte, error) {
+	b, ok := t.appendText(b)
+	if !ok {
+		return nil, errYearRange("Time.AppendRFC9557", t.Year())
+	}
+	return appendZoneSuffix(b, t.Location()), nil
+}
+
+// appendZoneSuffix appends the time zone suffix of loc, if it has an IANA time zone name.
+func appendZoneSuffix(b []byte, loc *time.Location) []byte {
+	if name := zoneName(loc); name != "" {
+		b = append(b, '[')
+		b = append(b, name...)
+		b = append(b, ']')
+	}
+	return b
+}
+
+// ianaNames caches whether each location name is an IANA time zone, because
+// time.LoadLocation reads the time zone database every time it is called.
+var ianaNames sync.Map
+
+// zoneName returns the IANA time zone name of loc, or "" if it does not have one.
+// UTC and Local are not named, nor are fixed zones unless their names happen to be
+// IANA time zones.
+func zoneName(loc *time.Location) string {
+	name := loc.String()
+	if loc == time.UTC || loc == time.Local || name == "" {
+		return ""
+	}
+
+	known, ok := ianaNames.Load(name)
+	if !ok {
+		_, err := time.LoadLocation(name)
+		known, _ = ianaNames.LoadOrStore(name, err == nil)
+	}
+	if !known.(bool) {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,178 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseRFC9557_ok(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+	plus1 := time.FixedZone("", 3600)
+
+	cases := []struct {
+		input    string
+		expected time.Time
+		tags     []Tag
+	}{
+		{
+			input:    "2022-07-08T00:14:07+01:00[Europe/London]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, london),
+		},
+		{
+			input:    "2022-07-08T00:14:07[Europe/London]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, london),
+		},
+		{
+			input:    "2022-01-08T00:14:07[Europe/London]",
+			expected: time.Date(2022, 1, 8, 0, 14, 7, 0, london),
+		},
+		{
+			input:    "2022-07-07T23:14:07Z[Europe/London]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, london),
+		},
+		{
+			input:    "2022-07-08T00:14:07+01:00[!Europe/London][u-ca=gregory]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, london),
+			tags:     []Tag{{Key: "u-ca", Value: "gregory"}},
+		},
+		{
+			input:    "2022-07-08T00:14:07+01:00[+01:00]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, plus1),
+		},
+		{
+			input:    "2022-07-08T00:14:07Z[u-ca=iso8601][!_foo=bar-42]",
+			expected: time.Date(2022, 7, 8, 0, 14, 7, 0, time.UTC),
+			tags:     []Tag{{Key: "u-ca", Value: "iso8601"}, {Key: "_foo", Value: "bar-42", Critical: true}},
+		},
+		{
+			input:    "2022-07-08[Europe/London]",
+			expected: time.Date(2022, 7, 8, 0, 0, 0, 0, london),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tm, tags, err := ParseRFC9557String(c.input)
+			expect.Error(err).ToBeNil(t)
+			expect.Any(tm.Time).ToBe(t, c.expected)
+			expect.Slice(tags).ToBe(t, c.tags...)
+			_, offset := tm.Zone()
+			_, expectedOffset := c.expected.Zone()
+			expect.Number(offset).ToBe(t, expectedOffset)
+		})
+	}
+}
+
+func TestParseRFC9557_error(t *testing.T) {
+	cases := map[string]string{
+		"2022-07-08T00:14:07+01:00[Europe/London":       `missing ']'`,
+		"2022-07-08T00:14:07+01:00[Europe/London]x":     `invalid suffix at 'x'`,
+		"2022-07-08T00:14:07+01:00[]":                   `invalid suffix at '['`,
+		"2022-07-08T00:14:07+01:00[u-ca=gregory][UTC]":  `invalid suffix at '['`,
+		"2022-07-08T00:14:07+01:00[Europe/London][UTC]": `invalid suffix at '['`,
+		"2022-07-08T00:14:07+01:00[Europe/Nowhere]":     `unknown time zone Europe/Nowhere`,
+		"2022-07-08T00:14:07+01:00[Europe/London?]":     `invalid time zone at '?'`,
+		"2022-07-08T00:14:07+01:00[+01:x0]":             `zone`,
+		"2022-07-08T00:14:07+01:00[U-CA=gregory]":       `invalid tag`,
+		"2022-07-08T00:14:07+01:00[u-ca=greg--ory]":     `invalid tag`,
+		"2022-07-08T00:14:07+01:00[u-ca=]":              `invalid tag`,
+		"2022-07-08T00:14:07+02:00[Europe/London]":      `offset +02:00 does not agree with Europe/London`,
+		"2022-07-08T00:14:07+02:00[!Europe/London]":     `offset +02:00 does not agree with Europe/London`,
+		"2022-07-08T25:14:07+01:00[Europe/London]":      `hour`,
+	}
+
+	for inp, msg := range cases {
+		t.Run(inp, func(t *testing.T) {
+			_, _, err := ParseRFC9557String(inp)
+			expect.Error(err).ToContain(t, msg)
+		})
+	}
+}
+
+func TestParseRFC9557_mismatchPolicy(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+
+	_, _, err = ParseRFC9557String("2022-07-08T00:14:07+02:00[Europe/London]")
+	expect.Error(err).ToContain(t, "does not agree")
+
+	tm, _, err := Parser{}.WithZoneMismatch(PreferOffset).ParseRFC9557([]byte("2022-07-08T00:14:07+02:00[Europe/London]"))
+	expect.Error(err).ToBeNil(t)
+	expect.Any(tm.Time).ToBe(t, time.Date(2022, 7, 7, 23, 14, 7, 0, london))
+	expect.String(tm.String()).ToBe(t, "2022-07-07T23:14:07+01:00")
+
+	tm, _, err = Parser{}.WithZoneMismatch(PreferZone).ParseRFC9557([]byte("2022-07-08T00:14:07+02:00[Europe/London]"))
+	expect.Error(err).ToBeNil(t)
+	expect.Any(tm.Time).ToBe(t, time.Date(2022, 7, 8, 0, 14, 7, 0, london))
+	expect.String(tm.String()).ToBe(t, "2022-07-08T00:14:07+01:00")
+
+	// a critical time zone always rejects a mismatch
+	_, _, err = Parser{}.WithZoneMismatch(PreferZone).ParseRFC9557([]byte("2022-07-08T00:14:07+02:00[!Europe/London]"))
+	expect.Error(err).ToContain(t, "does not agree")
+}
+
+func TestParse_withSuffix(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+
+	tm, err := ParseString("2022-07-08T00:14:07+01:00[Europe/London][u-ca=hebrew]")
+	expect.Any(tm, err).ToBe(t, Date(2022, 7, 8, 0, 14, 7, 0, london))
+	expect.Any(tm.Location()).ToBe(t, london)
+
+	_, err = ParseString("2022-07-08T00:14:07+01:00[Europe/London][!u-ca=gregory]")
+	expect.Error(err).ToBeNil(t)
+
+	_, err = ParseString("2022-07-08T00:14:07+01:00[Europe/London][!u-ca=hebrew]")
+	expect.Error(err).ToContain(t, "critical tag [!u-ca=hebrew] is not supported")
+}
+
+func TestTag_String(t *testing.T) {
+	expect.String(Tag{Key: "u-ca", Value: "gregory"}.String()).ToBe(t, "[u-ca=gregory]")
+	expect.String(Tag{Key: "u-ca", Value: "gregory", Critical: true}.String()).ToBe(t, "[!u-ca=gregory]")
+}
+
+func TestTime_FormatRFC9557(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+
+	japan, err := time.LoadLocation("Japan")
+	expect.Error(err).ToBeNil(t)
+
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	expect.Error(err).ToBeNil(t)
+
+	cases := map[string]Time{
+		// Amsterdam used local mean time, with an offset that has seconds, until 1937
+		"1930-07-08T12:00:00+01:19:32[Europe/Amsterdam]": Date(1930, 7, 8, 12, 0, 0, 0, amsterdam),
+		"2022-07-08T00:14:07+01:00[Europe/London]":       Date(2022, 7, 8, 0, 14, 7, 0, london),
+		"2022-07-08T00:14:07+09:00[Japan]":               Date(2022, 7, 8, 0, 14, 7, 0, japan),
+		"2022-07-09T00:14:07+01:00":                      Date(2022, 7, 9, 0, 14, 7, 0, time.FixedZone("Europe/Nowhere", 3600)),
+		"2022-01-08T00:14:07Z[Europe/London]":            Date(2022, 1, 8, 0, 14, 7, 0, london),
+		"2022-07-08T00:14:07Z":                           Date(2022, 7, 8, 0, 14, 7, 0, time.UTC),
+		"2022-07-08T00:14:07+01:00":                      Date(2022, 7, 8, 0, 14, 7, 0, time.FixedZone("BST", 3600)),
+	}
+
+	for expected, tm := range cases {
+		t.Run(expected, func(t *testing.T) {
+			expect.String(tm.FormatRFC9557()).ToBe(t, expected)
+			expect.String(tm.AppendRFC9557([]byte("x"))).ToEqual(t, "x"+expected)
+
+			// round trip
+			tm2, _, err := ParseRFC9557String(expected)
+			expect.Error(err).ToBeNil(t)
+			expect.Bool(tm2.Equal(tm)).ToBeTrue(t)
+			expect.String(tm2.FormatRFC9557()).ToBe(t, expected)
+		})
+	}
+}
+
+func TestTime_AppendRFC9557_error(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	expect.Error(err).ToBeNil(t)
+
+	_, err = Date(12017, 7, 8, 0, 14, 7, 0, london).AppendRFC9557(nil)
+	expect.Error(err).ToContain(t, "Time.AppendRFC9557: year 12017 is outside the range 0-9999")
+}
@@ -1,8 +1,10 @@
 package iso8601
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"time"
 )
 
@@ -13,19 +15,34 @@ const (
 	RFC3339Nano  = time.RFC3339Nano
 )
 
+// These layouts use the comma as the decimal sign, which ISO-8601 prefers
+// but RFC3339 does not allow.
+const (
+	ISO8601MilliComma = "2006-01-02T15:04:05,999Z07:00"
+	ISO8601MicroComma = "2006-01-02T15:04:05,999999Z07:00"
+	ISO8601NanoComma  = "2006-01-02T15:04:05,999999999Z07:00"
+)
+
 // MarshalTextFormat is the rendering format used by MarshalText and MarshalJSON.
 // The default, RFC3339Nano, is suitable in most cases. However, if reduced
 // precision is required (e.g. when communicating with legacy systems such as
 // Salesforce), then this should be set to RFC3339Micro, RFC3339Milli or RFC3339.
+// If a consumer requires a comma as the decimal sign, use ISO8601NanoComma,
+// ISO8601MicroComma or ISO8601MilliComma.
 //
 // This must not be altered concurrently.
 //
 // If there is a need to marshal using various precision formats, not just one,
-// then the values should be rounded using Truncate. Round can also be used, but
-// note that the rounding might allow more digits to be sent.
+// then use TimeSecond, TimeMilli, TimeMicro or TimeNano, which do not depend on
+// this setting. Alternatively, the values can be rounded using Truncate. Round can
+// also be used, but note that the rounding might allow more digits to be sent.
 var MarshalTextFormat = RFC3339Nano
 
-var _ json.Unmarshaler = &Time{}
+var (
+	_ json.Unmarshaler = &Time{}
+	_ sql.Scanner      = &Time{}
+	_ driver.Valuer    = Time{}
+)
 
 // Date returns the Time corresponding to
 //
@@ -56,6 +73,8 @@ func Of(t time.Time) Time {
 
 // Time adapts time.Time for formatting and parsing ISO-8061 dates,
 // especially as a JSON string.
+//
+// The precision with which a Time was written is not kept; see PreciseTime.
 type Time struct {
 	time.Time
 }
@@ -117,28 +136,27 @@ func (t Time) Round(d time.Duration) Time {
 // MarshalText implements the encoding.TextMarshaler interface.
 // The time is formatted in ISO-8601 / RFC 3339 format, with sub-second
 // precision controlled by MarshalTextFormat.
+// Years outside the range 0-9999 are an error, as RFC 3339 requires four-digit years;
+// WithStyle allows them to be rendered as expanded years by a Formatter.
 func (t Time) MarshalText() ([]byte, error) {
-	if y := t.Year(); y < 0 || y >= 10000 {
-		return nil, errors.New("Time.MarshalText: year outside of range [0,9999]")
+	b, ok := t.appendText(make([]byte, 0, len(MarshalTextFormat)))
+	if !ok {
+		return nil, errYearRange("Time.MarshalText", t.Year())
 	}
-
-	b := make([]byte, 0, len(MarshalTextFormat))
-	return t.AppendFormat(b, MarshalTextFormat), nil
+	return b, nil
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 // The time is a quoted string in ISO-8601 / RFC 3339 format, with sub-second
 // precision controlled by MarshalTextFormat.
+// Years outside the range 0-9999 are an error, as for MarshalText.
 func (t Time) MarshalJSON() ([]byte, error) {
-	if y := t.Year(); y < 0 || y >= 10000 {
-		// RFC 3339 is clear that years are 4 digits exactly.
-		// See golang.org/issue/4556#c15 for more discussion.
-		return nil, errors.New("Time.MarshalJSON: year outside of range [0,9999]")
-	}
-
 	b := make([]byte, 0, len(MarshalTextFormat)+2)
 	b = append(b, '"')
-	b = t.AppendFormat(b, MarshalTextFormat)
+	b, ok := t.appendText(b)
+	if !ok {
+		return nil, errYearRange("Time.MarshalJSON", t.Year())
+	}
 	b = append(b, '"')
 	return b, nil
 }
@@ -153,20 +171,40 @@ func (t *Time) UnmarshalText(data []byte) (err error) {
 
 // UnmarshalJSON decodes a JSON string or null into a iso8601 time
 func (t *Time) UnmarshalJSON(b []byte) error {
-	// Do not process null types
-	if null(b) {
-		return nil
-	}
-	if len(b) > 0 && b[0] == '"' && b[len(b)-1] == '"' {
-		b = b[1 : len(b)-1]
-	} else {
-		return ErrNotString
+	b, ok, err := unquote(b)
+	if !ok {
+		return err
 	}
-	var err error
 	*t, err = Parse(b)
 	return err
 }
 
+// Scan implements the sql.Scanner interface. It accepts a time.Time, which is typical of
+// TIMESTAMP and DATETIME columns, or a string or []byte holding an ISO-8601 date-time, as
+// some drivers (e.g. for SQLite) provide. Text is parsed leniently, so the space between the
+// date and time that databases often use is accepted. A nil value gives the zero time; see
+// also NullTime.
+func (t *Time) Scan(value any) (err error) {
+	switch v := value.(type) {
+	case nil:
+		*t = Time{}
+	case time.Time:
+		*t = Of(v)
+	case string:
+		*t, err = defaultParser().WithLenient().ParseString(v)
+	case []byte:
+		*t, err = defaultParser().WithLenient().Parse(v)
+	default:
+		err = fmt.Errorf("Time.Scan: unsupported type %T", value)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface. The time is given as a time.Time.
+func (t Time) Value() (driver.Value, error) {
+	return t.Time, nil
+}
+
 // null returns true if the given byte slice is a JSON null.
 // This is about 3x faster than `bytes.Compare`.
 func null(b []byte) bool {
@@ -179,6 +217,19 @@ func null(b []byte) bool {
 	return true
 }
 
+// unquote returns the content of the JSON string b. It returns false if b is a JSON null,
+// which leaves the value unchanged, or if b is not a string, which is an ErrNotString.
+func unquote(b []byte) ([]byte, bool, error) {
+	// Do not process null types
+	if null(b) {
+		return nil, false, nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return nil, false, ErrNotString
+	}
+	return b[1 : len(b)-1], true, nil
+}
+
 // Unix returns the local Time corresponding to the given Unix time, sec seconds and nsec
 // nanoseconds since January 1, 1970 UTC. It is valid to pass nsec outside the range
 // [0, 999999999]. Not all sec values have a corresponding time value. One such value
@@ -247,5 +298,5 @@ func (t Time) ZoneBounds() (start, end Time) {
 // Now is a pluggable function to lookup the system clock. The returned time is in the Local timezone.
 // In unit tests, this can be replaced with a generator function.
 var Now = func() Time {
-	return Time{time.Now()}
+	return Of(time.Now())
 }
@@ -67,6 +67,7 @@ func TestTime_Unmarshaling(t *testing.T) {
 		expect.Number(tn.Day()).ToBe(t, ShortTest.Day)
 
 		expect.Any(tn.UnmarshalJSON([]byte(`2001-11-13`))).ToBe(t, ErrNotString)
+		expect.Any(tn.UnmarshalJSON([]byte(`"`))).ToBe(t, ErrNotString)
 	})
 
 	t.Run("struct", func(t *testing.T) {
@@ -143,6 +144,21 @@ func TestTime_Marshaling(t *testing.T) {
 			resolution: time.Microsecond,
 			expected:   "2017-04-26T11:13:04.123456Z",
 		},
+		{
+			format:     ISO8601MilliComma,
+			resolution: time.Millisecond,
+			expected:   "2017-04-26T11:13:04,123Z",
+		},
+		{
+			format:     ISO8601MicroComma,
+			resolution: time.Microsecond,
+			expected:   "2017-04-26T11:13:04,123456Z",
+		},
+		{
+			format:     ISO8601NanoComma,
+			resolution: time.Nanosecond,
+			expected:   "2017-04-26T11:13:04,123456789Z",
+		},
 		{
 			format:     RFC3339Nano,
 			resolution: time.Nanosecond,
@@ -179,6 +195,238 @@ func TestTime_Marshaling(t *testing.T) {
 	})
 }
 
+func TestTime_comparable(t *testing.T) {
+	// the precision is not part of a Time, so equal instants in the same location are ==
+	a, err := ParseString("2017-04")
+	expect.Error(err).ToBeNil(t)
+	b := Time{time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC)}
+	expect.Bool(a == b).ToBeTrue(t)
+
+	seen := map[Time]bool{a: true}
+	expect.Bool(seen[b]).ToBeTrue(t)
+}
+
+func TestTime_Marshaling_expandedYear(t *testing.T) {
+	cases := []struct {
+		value    PreciseTime
+		expected string
+	}{
+		{Date(-44, 3, 15, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}), "-000044-03-15T00:00:00Z"},
+		{Date(12345, 4, 24, 9, 41, 34, 502000000, time.UTC).WithStyle(Formatter{}.WithDecimals(3)), "+012345-04-24T09:41:34.502Z"},
+		{Date(2017, 4, 24, 9, 41, 34, 0, time.UTC).WithStyle(Formatter{}), "2017-04-24T09:41:34Z"},
+		{Date(-44, 3, 15, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}.WithPrecision(PrecisionMonth)), "-000044-03"},
+		{Date(-4400, 3, 15, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}.WithPrecision(PrecisionCentury)), "-0044"},
+		{Date(-150, 3, 15, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}.WithPrecision(PrecisionCentury)), "-0002"},
+		{Date(12345, 4, 24, 0, 0, 0, 0, time.UTC).WithStyle(Formatter{}.WithForm(FormWeek).WithPrecision(PrecisionWeek)), "+012345-W17"},
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, c := range []PreciseTime{cases[0].value, cases[1].value, cases[3].value, cases[4].value, cases[6].value} {
+			_, err := c.Time.MarshalText()
+			expect.Error(err).ToContain(t, "Time.MarshalText: year ")
+			_, err = c.Time.MarshalJSON()
+			expect.Error(err).ToContain(t, "Time.MarshalJSON: year ")
+			_, err = c.Time.WithPrecision(PrecisionDay).MarshalText()
+			expect.Error(err).ToContain(t, "PreciseTime.MarshalText: year ")
+		}
+
+		_, err := Date(-44, 3, 15, 0, 0, 0, 0, time.UTC).MarshalText()
+		expect.Error(err).ToContain(t, "Time.MarshalText: year -44 is outside the range 0-9999; use a Formatter to render it as an expanded year")
+	})
+
+	t.Run("expanded", func(t *testing.T) {
+		for _, c := range cases {
+			b, err := c.value.MarshalText()
+			expect.String(b, err).ToEqual(t, c.expected)
+
+			b, err = json.Marshal(c.value)
+			expect.String(b, err).ToEqual(t, `"`+c.expected+`"`)
+
+			// a Parser that retains the style round-trips the expanded year
+			tn, err := Parser{}.WithRetainText().ParseWithPrecision([]byte(c.expected))
+			expect.Error(err).Not().ToHaveOccurred(t)
+
+			b, err = tn.MarshalText()
+			expect.String(b, err).ToEqual(t, c.expected)
+		}
+	})
+}
+
+func TestTime_Marshaling_endOfDay(t *testing.T) {
+	cases := []struct {
+		input, normal, endOfDay string
+	}{
+		{"2017-04-24T24:00:00Z", "2017-04-25T00:00:00Z", "2017-04-24T24:00:00Z"},
+		{"2017-12-31T24:00:00.0+01:00", "2018-01-01T00:00:00+01:00", "2017-12-31T24:00:00+01:00"},
+		{"2017-02-28T24:00", "2017-03-01T00:00:00Z", "2017-02-28T24:00:00Z"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tm, err := ParseWithPrecision([]byte(c.input))
+			expect.Error(err).ToBeNil(t)
+			expect.Bool(tm.IsEndOfDay()).ToBeTrue(t)
+
+			b, err := tm.WithPrecision(PrecisionUnspecified).MarshalText()
+			expect.String(b, err).ToEqual(t, c.normal)
+
+			tm, err = Parser{}.WithMarshalEndOfDay().ParseWithPrecision([]byte(c.input))
+			expect.Error(err).ToBeNil(t)
+			expect.Bool(tm.IsEndOfDay()).ToBeTrue(t)
+			tm = tm.WithPrecision(PrecisionUnspecified)
+
+			b, err = tm.MarshalText()
+			expect.String(b, err).ToEqual(t, c.endOfDay)
+
+			b, err = json.Marshal(tm)
+			expect.String(b, err).ToEqual(t, `"`+c.endOfDay+`"`)
+		})
+	}
+
+	p := Parser{}.WithMarshalEndOfDay()
+
+	t.Run("precision", func(t *testing.T) {
+		tm, err := p.ParseWithPrecision([]byte("2017-04-24T24:00Z"))
+		expect.Error(err).ToBeNil(t)
+		b, err := tm.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T24:00Z")
+
+		b, err = tm.WithPrecision(PrecisionDay).MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-25")
+	})
+
+	t.Run("not end of day", func(t *testing.T) {
+		tm, err := p.ParseWithPrecision([]byte("2017-04-25T00:00:00Z"))
+		expect.Error(err).ToBeNil(t)
+		expect.Bool(tm.IsEndOfDay()).ToBeFalse(t)
+		b, err := tm.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-25T00:00:00Z")
+	})
+}
+
+func TestPreciseTime_Marshaling_retainText(t *testing.T) {
+	cases := []string{
+		"2017-04-24T09:41+01",
+		"2017-04-24T09:41:34,5+0100",
+		"20170424T094134.500Z",
+		"2017-W17-1T09:41:34.502-05:00",
+		"2017W171T0941",
+		"2017-114",
+		"2017-W17",
+		"20",
+		"+002017-04-24T09.25+00:00",
+		"2017-04-24T24:00Z",
+		"2022-07-08T00:14:07+01:00[Europe/London]",
+	}
+
+	p := Parser{}.WithRetainText()
+
+	for _, inp := range cases {
+		t.Run(inp, func(t *testing.T) {
+			tm, err := p.ParseWithPrecision([]byte(inp))
+			expect.Error(err).ToBeNil(t)
+
+			b, err := tm.MarshalText()
+			expect.String(b, err).ToEqual(t, inp)
+			b, err = json.Marshal(tm)
+			expect.String(b, err).ToEqual(t, `"`+inp+`"`)
+
+			style, ok := tm.Style()
+			expect.Bool(ok).ToBeTrue(t)
+			expect.String(style.Format(tm.Time)).ToBe(t, inp)
+
+			// unmarshaling retains the style too
+			var v struct{ T PreciseTime }
+			err = json.Unmarshal([]byte(`{"T":"`+inp+`"}`), &v)
+			expect.Error(err).ToBeNil(t)
+			b, err = json.Marshal(v)
+			expect.String(b, err).ToEqual(t, `{"T":"`+inp+`"}`)
+
+			var u PreciseTime
+			err = u.UnmarshalText([]byte(inp))
+			expect.Error(err).ToBeNil(t)
+			b, err = u.MarshalText()
+			expect.String(b, err).ToEqual(t, inp)
+		})
+	}
+
+	t.Run("limits", func(t *testing.T) {
+		// the style is not the text itself
+		cases := map[string]string{
+			"2017-4-24":                         "2017-04-24",
+			"2017-04-24T09:41+01[u-ca=gregory]": "2017-04-24T09:41+01",
+			"2017-04-24T09:41+01:00[+01:00]":    "2017-04-24T09:41+01:00",
+		}
+		for inp, expected := range cases {
+			var u PreciseTime
+			err := u.UnmarshalText([]byte(inp))
+			expect.Error(err).ToBeNil(t)
+			b, err := u.MarshalText()
+			expect.String(b, err).ToEqual(t, expected)
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		tm, err := p.ParseWithPrecision([]byte("2017-04-24T09:41,5+01"))
+		expect.Error(err).ToBeNil(t)
+
+		// the style survives changes to the time
+		tm.Time = tm.Add(90 * time.Minute)
+		b, err := tm.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T11:11,5+01")
+
+		tm.Time = tm.In(time.FixedZone("", -5*3600))
+		b, err = tm.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T05:11,5-05")
+
+		// but not a change of precision
+		b, err = tm.WithPrecision(PrecisionDay).MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24")
+	})
+
+	t.Run("not retained", func(t *testing.T) {
+		tm, err := ParseWithPrecision([]byte("2017-04-24T09:41+01"))
+		expect.Error(err).ToBeNil(t)
+		b, err := tm.MarshalText()
+		expect.String(b, err).ToEqual(t, "2017-04-24T09:41+01:00")
+		_, ok := tm.Style()
+		expect.Bool(ok).ToBeFalse(t)
+	})
+}
+
+func TestTime_sql(t *testing.T) {
+	plus1 := time.FixedZone("", 3600)
+	tm := Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1)
+
+	v, err := tm.Value()
+	expect.Any(v, err).ToBe(t, time.Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1))
+
+	cases := []any{
+		time.Date(2017, 4, 24, 9, 41, 34, 502_000_000, plus1),
+		"2017-04-24T09:41:34.502+01:00",
+		[]byte("2017-04-24T09:41:34.502+01:00"),
+		"2017-04-24 09:41:34.502+01",
+		"2017-04-24 08:41:34.502Z",
+	}
+
+	for _, c := range cases {
+		var t2 Time
+		err = t2.Scan(c)
+		expect.Error(err).ToBeNil(t)
+		expect.Bool(t2.Equal(tm)).ToBeTrue(t)
+	}
+
+	t2 := tm
+	err = t2.Scan(nil)
+	expect.Any(t2, err).ToBe(t, Time{})
+
+	err = t2.Scan(42)
+	expect.Error(err).ToContain(t, "Time.Scan: unsupported type int")
+
+	err = t2.Scan("2017-04-31 09:41:34Z")
+	expect.Error(err).ToContain(t, "day 31")
+}
+
 func TestTime_Decorators(t *testing.T) {
 	ny, err := time.LoadLocation("America/New_York")
 	expect.Error(err).ToBeNil(t)
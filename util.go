@@ -1,6 +1,9 @@
 package iso8601
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // The following is copied from the Go standard library to implement date range validation logic
 // equivalent to the behaviour of Go's time.Parse.
@@ -18,3 +21,84 @@ func daysIn(m time.Month, year int) int {
 	}
 	return daysInMonth[int(m)]
 }
+
+// appendInt appends the decimal form of v to b, zero-padded to at least width digits.
+func appendInt(b []byte, v, width int) []byte {
+	if v < 0 {
+		b = append(b, '-')
+		v = -v
+	}
+
+	var buf [20]byte
+	i := len(buf)
+	for v > 0 || width > 0 || i == len(buf) {
+		i--
+		buf[i] = byte(v%10 + charStart)
+		v /= 10
+		width--
+	}
+	return append(b, buf[i:]...)
+}
+
+// appendYear appends the year (or century) y to b, zero-padded to width digits. Years that
+// do not fit are written as expanded years with a sign and two extra digits.
+func appendYear(b []byte, y, width int) []byte {
+	return Formatter{}.appendYear(b, y, width)
+}
+
+// zoneLayouts holds the numeric zone elements of reference layouts, longest first.
+var zoneLayouts = [...]string{"Z07:00:00", "-07:00:00", "Z070000", "-070000", "Z07:00", "-07:00", "Z0700", "-0700", "Z07", "-07"}
+
+// zoneLayout returns the numeric zone element at the end of layout, or "" if there is none.
+func zoneLayout(layout string) string {
+	for _, z := range zoneLayouts {
+		if strings.HasSuffix(layout, z) {
+			return z
+		}
+	}
+	return ""
+}
+
+// withOffsetSeconds extends the zone element of layout to include seconds if the zone offset
+// of t has seconds, as local mean times such as Amsterdam's before 1937 do. Otherwise the
+// offset would be truncated and could not be parsed back.
+func withOffsetSeconds(layout string, t time.Time) string {
+	if _, offset := t.Zone(); offset%60 != 0 {
+		switch zoneLayout(layout) {
+		case "Z07:00", "-07:00":
+			return layout + ":00"
+		case "Z0700", "-0700":
+			return layout + "00"
+		}
+	}
+	return layout
+}
+
+// isMidnight reports whether t is exactly midnight at the start of a day.
+func isMidnight(t time.Time) bool {
+	hh, mm, ss := t.Clock()
+	return hh == 0 && mm == 0 && ss == 0 && t.Nanosecond() == 0
+}
+
+// floorDiv divides a by b, rounding towards negative infinity, so that -150 is in century -2
+// just as 150 is in century 1.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func pow10(n int) int {
+	p := 1
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
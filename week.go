@@ -0,0 +1,138 @@
+package iso8601
+
+import (
+	"fmt"
+	"time"
+)
+
+// Week is an ISO-8601 week, identified by its ISO week-numbering year and its week number
+// within that year. Weeks start on Monday; week 1 is the week containing the year's first
+// Thursday, so the ISO year can differ from the calendar year for days near New Year.
+type Week struct {
+	Year int // ISO week-numbering year
+	Week int // 1 to 52 or 53
+}
+
+// WeekOf returns the ISO-8601 week in which t occurs.
+func WeekOf(t Time) Week {
+	y, w := t.ISOWeek()
+	return Week{Year: y, Week: w}
+}
+
+// ParseWeek parses an ISO-8601 week, either YYYY-Www (extended) or YYYYWww (basic).
+// If the week number is not within the range for the year then an *iso8601.RangeError is returned.
+func ParseWeek(inp []byte) (Week, error) {
+	return defaultParser().ParseWeek(inp)
+}
+
+// ParseWeek is like the ParseWeek function but uses the parser's forms, expanded year digits
+// and white space setting. The profile does not apply, because it is for date-times.
+func (p Parser) ParseWeek(inp []byte) (Week, error) {
+	b := p.trim(inp)
+	w, err := p.parseWeek(b)
+	if err != nil {
+		return Week{}, withValue(err, b, inp)
+	}
+	return w, nil
+}
+
+// parseWeek parses the week, once any white space has been trimmed.
+func (p Parser) parseWeek(inp []byte) (Week, error) {
+	f := p.newFields()
+	j, err := f.parseDate(inp, 0)
+	if err != nil {
+		return Week{}, err
+	}
+
+	// the input must end with the week number: there must not be a day of the week
+	if f.form != weekDate || j != len(inp) || inp[j-3] != 'W' {
+		return Week{}, &SyntaxError{Value: string(inp), Element: "week"}
+	}
+
+	if err = p.checkForms(inp, &f); err != nil {
+		return Week{}, err
+	}
+
+	w := Week{Year: f.Y, Week: f.w}
+	if err = f.validate(inp); err != nil {
+		return Week{}, err
+	}
+	return w, nil
+}
+
+// Weekday returns the Time for the given day of week w, at midnight in the given location.
+func (w Week) Weekday(d time.Weekday, loc *time.Location) Time {
+	y, m, day := fromWeekDate(w.Year, w.Week, isoWeekday(d))
+	return Date(y, time.Month(m), day, 0, 0, 0, 0, loc)
+}
+
+// String renders the week in the ISO-8601 extended format YYYY-Www.
+func (w Week) String() string {
+	return string(w.appendTo(make([]byte, 0, 8)))
+}
+
+func (w Week) appendTo(b []byte) []byte {
+	b = appendYear(b, w.Year, 4)
+	b = append(b, '-', 'W')
+	return appendInt(b, w.Week, 2)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The week is formatted as YYYY-Www. It is an error if the year is outside the range 0-9999
+// or the week is not within the range for the year, so that the text can always be parsed
+// by ParseWeek.
+func (w Week) MarshalText() ([]byte, error) {
+	if w.Year < 0 || w.Year >= 10000 {
+		return nil, errYearRange("Week.MarshalText", w.Year)
+	}
+	if n := weeksIn(w.Year); w.Week < 1 || w.Week > n {
+		return nil, fmt.Errorf("Week.MarshalText: week %d is not in range 1-%d", w.Week, n)
+	}
+	return w.appendTo(make([]byte, 0, 8)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The week is expected to be YYYY-Www or YYYYWww.
+func (w *Week) UnmarshalText(data []byte) (err error) {
+	*w, err = ParseWeek(data)
+	return err
+}
+
+// FormatWeekDate renders the date part of t as an ISO-8601 week date in extended format,
+// YYYY-Www-D, where D is the day of the week from 1 (Monday) to 7 (Sunday).
+// The time of day can be appended using Format, e.g. t.Format("T15:04:05Z07:00").
+func (t Time) FormatWeekDate() string {
+	return string(t.AppendWeekDate(make([]byte, 0, 10)))
+}
+
+// AppendWeekDate is like FormatWeekDate but appends the textual representation to b
+// and returns the extended buffer.
+func (t Time) AppendWeekDate(b []byte) []byte {
+	b = WeekOf(t).appendTo(b)
+	return append(b, '-', byte(isoWeekday(t.Weekday())+charStart))
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// isoWeekday converts d to the ISO-8601 numbering, 1 (Monday) to 7 (Sunday).
+func isoWeekday(d time.Weekday) int {
+	if d == time.Sunday {
+		return 7
+	}
+	return int(d)
+}
+
+// weeksIn returns the number of ISO weeks in a year, which is 52 or 53.
+func weeksIn(year int) int {
+	// 28th December is always in the last week of the year
+	_, w := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+// fromWeekDate converts an ISO week date to the corresponding calendar date.
+func fromWeekDate(year, week, weekday int) (int, int, int) {
+	// 4th January is always in week 1
+	jan4 := isoWeekday(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC).Weekday())
+	y, m, d := time.Date(year, time.January, 4-jan4+7*(week-1)+weekday, 0, 0, 0, 0, time.UTC).Date()
+	return y, int(m), d
+}
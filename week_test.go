@@ -0,0 +1,75 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestParseWeek(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		expect.Any(ParseWeek([]byte("2017-W17"))).ToBe(t, Week{Year: 2017, Week: 17})
+		expect.Any(ParseWeek([]byte("2017W17"))).ToBe(t, Week{Year: 2017, Week: 17})
+		expect.Any(ParseWeek([]byte("2015-W53"))).ToBe(t, Week{Year: 2015, Week: 53})
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expect.Error(ParseWeek([]byte("2017-W53"))).ToContain(t, `Cannot parse "2017-W53": week 53 is not in range 1-52`)
+		expect.Error(ParseWeek([]byte("2017-W17-1"))).ToContain(t, `Cannot parse "2017-W17-1": invalid week`)
+		expect.Error(ParseWeek([]byte("2017-04"))).ToContain(t, `Cannot parse "2017-04": invalid week`)
+		expect.Error(ParseWeek([]byte("2017-W1"))).ToContain(t, `Cannot parse "2017-W1": invalid week`)
+	})
+}
+
+func TestWeek(t *testing.T) {
+	w := Week{Year: 2017, Week: 17}
+
+	t.Run("WeekOf", func(t *testing.T) {
+		expect.Any(WeekOf(Date(2017, 4, 24, 9, 41, 0, 0, time.UTC))).ToBe(t, w)
+		expect.Any(WeekOf(Date(2017, 4, 30, 23, 59, 0, 0, time.UTC))).ToBe(t, w)
+		expect.Any(WeekOf(Date(2016, 1, 3, 0, 0, 0, 0, time.UTC))).ToBe(t, Week{Year: 2015, Week: 53})
+	})
+
+	t.Run("Weekday", func(t *testing.T) {
+		expect.Any(w.Weekday(time.Monday, time.UTC)).ToBe(t, Date(2017, 4, 24, 0, 0, 0, 0, time.UTC))
+		expect.Any(w.Weekday(time.Sunday, time.UTC)).ToBe(t, Date(2017, 4, 30, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("String", func(t *testing.T) {
+		expect.String(w.String()).ToBe(t, "2017-W17")
+		expect.String(Week{Year: 2020, Week: 1}.String()).ToBe(t, "2020-W01")
+	})
+
+	t.Run("JSON marshal/unmarshal", func(t *testing.T) {
+		b, err := json.Marshal(w)
+		expect.String(b, err).ToEqual(t, `"2017-W17"`)
+
+		var w2 Week
+		err = json.Unmarshal(b, &w2)
+		expect.Any(w2, err).ToBe(t, w)
+
+		_, err = json.Marshal(Week{Year: 10000, Week: 1})
+		expect.Error(err).ToContain(t, "Week.MarshalText: year 10000 is outside the range 0-9999")
+
+		// only weeks that ParseWeek accepts can be marshaled
+		_, err = Week{Year: 2017, Week: 60}.MarshalText()
+		expect.Error(err).ToContain(t, "Week.MarshalText: week 60 is not in range 1-52")
+		_, err = Week{Year: 2017, Week: 53}.MarshalText()
+		expect.Error(err).ToContain(t, "week 53 is not in range 1-52")
+		_, err = Week{Year: 2017, Week: 0}.MarshalText()
+		expect.Error(err).ToContain(t, "week 0 is not in range 1-52")
+		b, err = Week{Year: 2015, Week: 53}.MarshalText()
+		expect.String(b, err).ToEqual(t, "2015-W53")
+	})
+}
+
+func TestTime_FormatWeekDate(t *testing.T) {
+	expect.String(Date(2017, 4, 24, 9, 41, 0, 0, time.UTC).FormatWeekDate()).ToBe(t, "2017-W17-1")
+	expect.String(Date(2016, 1, 3, 0, 0, 0, 0, time.UTC).FormatWeekDate()).ToBe(t, "2015-W53-7")
+	expect.String(Date(2018, 12, 31, 0, 0, 0, 0, time.UTC).FormatWeekDate()).ToBe(t, "2019-W01-1")
+
+	b := Date(2017, 4, 24, 9, 41, 0, 0, time.UTC).AppendWeekDate([]byte("x"))
+	expect.String(b).ToEqual(t, "x2017-W17-1")
+}